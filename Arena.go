@@ -0,0 +1,105 @@
+package implot
+
+// #include <stdlib.h>
+import "C"
+import "unsafe"
+
+// stringArena is a bump allocator of C memory, for the strings and small
+// arrays passed to ImPlot (labels, titles, tick labels and values).
+//
+// Every Context has one. It is reset at every BeginPlot, BeginSubplots and
+// EndPlot, so the memory only has to live as long as the plot using it, and
+// it must only be used between them. The calls outside of plots (such as
+// ShowDatePicker or PushColormapName) use C.CString instead. After a few
+// frames the arena settles into one chunk large enough for a whole plot,
+// and no more allocations are made, in Go or in C.
+type stringArena struct {
+	chunks []unsafe.Pointer // C memory, the last one is being filled
+	size   int              // size of the last chunk
+	used   int              // bytes used in the last chunk
+	total  int              // bytes used in all the chunks since the last reset
+	hint   int              // minimal size of the next chunk
+}
+
+// arenaChunkSize is the minimal size of an arena chunk.
+const arenaChunkSize = 16 << 10
+
+// alloc returns #n bytes of C memory, aligned to #align (a power of 2).
+func (a *stringArena) alloc(n, align int) unsafe.Pointer {
+	if len(a.chunks) > 0 {
+		off := (a.used + align - 1) &^ (align - 1)
+		if off+n <= a.size {
+			a.total += off + n - a.used
+			a.used = off + n
+			return unsafe.Add(a.chunks[len(a.chunks)-1], off)
+		}
+	}
+
+	// C.malloc is aligned for any type, so a new chunk starts aligned
+	a.size = arenaChunkSize
+	for _, s := range [...]int{a.hint, 2 * a.total, n} {
+		if s > a.size {
+			a.size = s
+		}
+	}
+	p := C.malloc(C.size_t(a.size))
+	a.chunks = append(a.chunks, p)
+	a.used = n
+	a.total += n
+	return p
+}
+
+// reset frees everything allocated from the arena.
+//
+// If more than one chunk was used, they are merged into one
+// large enough for all of them at the next allocation.
+func (a *stringArena) reset() {
+	if len(a.chunks) > 1 {
+		hint := a.total
+		a.free()
+		a.hint = hint
+		return
+	}
+	a.used = 0
+	a.total = 0
+}
+
+// free releases all the memory of the arena.
+func (a *stringArena) free() {
+	for _, p := range a.chunks {
+		C.free(p)
+	}
+	a.chunks = a.chunks[:0]
+	a.size, a.used, a.total, a.hint = 0, 0, 0, 0
+}
+
+// string returns a copy of #str as a C string.
+func (a *stringArena) string(str string) *C.char {
+	p := a.alloc(len(str)+1, 1)
+	b := unsafe.Slice((*byte)(p), len(str)+1)
+	b[copy(b, str)] = 0
+	return (*C.char)(p)
+}
+
+// strings returns a C array of C strings copied from #slice, or nil if it is empty.
+func (a *stringArena) strings(slice []string) **C.char {
+	if len(slice) == 0 {
+		return nil
+	}
+	p := a.alloc(len(slice)*int(unsafe.Sizeof((*C.char)(nil))), int(unsafe.Alignof((*C.char)(nil))))
+	ps := unsafe.Slice((**C.char)(p), len(slice))
+	for i, s := range slice {
+		ps[i] = a.string(s)
+	}
+	return (**C.char)(p)
+}
+
+// doubles returns a C array of doubles copied from #slice, or nil if it is empty.
+func (a *stringArena) doubles(slice []float64) *C.double {
+	if len(slice) == 0 {
+		return nil
+	}
+	p := a.alloc(len(slice)*int(unsafe.Sizeof(C.double(0))), int(unsafe.Alignof(C.double(0))))
+	copy(unsafe.Slice((*float64)(p), len(slice)), slice)
+	return (*C.double)(p)
+}
@@ -0,0 +1,417 @@
+package implot
+
+// #include <stdlib.h>
+// #include "wrapper/Batch.h"
+import "C"
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/inkyblackness/imgui-go/v4"
+)
+
+// Batch records Setup, Style and Plot calls, to be replayed later with a
+// single cgo call. Dense plots with many small items spend most of their
+// time crossing the cgo boundary, one crossing per call; a Batch makes it
+// one crossing per plot:
+//
+//	var b implot.Batch // reused every frame
+//	...
+//	if implot.BeginPlot("Dashboard") {
+//		b.SetupAxis(implot.Axis_X1, "Time", implot.AxisFlags_None)
+//		for _, s := range series {
+//			b.PlotLine(s.Name, s.Values)
+//		}
+//		b.Replay()
+//		implot.EndPlot()
+//	}
+//
+// The methods mirror the functions of the same names, and Replay calls
+// exactly what the functions would, in the same order. Only float64 data
+// is supported, as methods cannot be generic.
+//
+// Labels and data are copied into C memory owned by the Batch when recorded,
+// so the slices can be reused right away. The data is not referenced: every
+// series is copied again every frame it is recorded, which for large series
+// can cost more than the cgo calls saved. Plot those directly. Replay must be called inside
+// BeginPlot/EndPlot, and clears the Batch afterwards. Once the Batch has
+// grown to the size of a frame, recording and replaying do not allocate.
+//
+// The zero Batch is empty and ready to use. Call Free to release its C memory.
+type Batch struct {
+	cmds  *C.igpCmd // C array
+	n     int       // number of commands recorded
+	cap   int       // capacity of cmds
+	arena stringArena
+}
+
+// Len returns the number of commands recorded.
+func (b *Batch) Len() int { return b.n }
+
+// Reset discards all the commands recorded, keeping the memory for reuse.
+func (b *Batch) Reset() {
+	b.n = 0
+	b.arena.reset()
+}
+
+// Free releases the C memory of the Batch. It can still be used afterwards.
+func (b *Batch) Free() {
+	C.free(unsafe.Pointer(b.cmds))
+	b.cmds, b.n, b.cap = nil, 0, 0
+	b.arena.free()
+}
+
+// Replay calls all the commands recorded, in order, then resets the Batch.
+//
+// The data recorded is not transformed by the scales set by SetupAxisScale,
+// so it panics if the plot has any.
+func (b *Batch) Replay() {
+	if current().scaled {
+		panic(fmt.Errorf("implot: Batch.Replay: %w", ErrScaleUnsupported))
+	}
+	cmds := unsafe.Slice(b.cmds, b.n)
+	for i := range cmds {
+		validateCmd(&cmds[i])
+	}
+	C.igpReplay(b.cmds, C.int(b.n))
+	b.Reset()
+}
+
+// push appends a zeroed command.
+func (b *Batch) push(op C.igpOp) *C.igpCmd {
+	if b.n == b.cap {
+		b.cap = b.cap*2 + 64
+		b.cmds = (*C.igpCmd)(C.realloc(unsafe.Pointer(b.cmds), C.size_t(b.cap)*C.size_t(unsafe.Sizeof(C.igpCmd{}))))
+	}
+	c := (*C.igpCmd)(unsafe.Add(unsafe.Pointer(b.cmds), uintptr(b.n)*unsafe.Sizeof(C.igpCmd{})))
+	*c = C.igpCmd{op: op}
+	b.n++
+	return c
+}
+
+// pushValues appends a PlotXXX(values) command, with d0 = xscale and d1 = x0.
+func (b *Batch) pushValues(op C.igpOp, label string, values []float64, xscale, x0 float64) *C.igpCmd {
+	c := b.push(op)
+	c._type = C.igpDataType_Double
+	c.text = b.arena.string(label)
+	c.ys = unsafe.Pointer(b.arena.doubles(values))
+	c.count = C.int(len(values))
+	c.stride = C.int(unsafe.Sizeof(float64(0)))
+	c.d0, c.d1 = C.double(xscale), C.double(x0)
+	return c
+}
+
+// pushXY appends a PlotXXX(xs, ys) command.
+func (b *Batch) pushXY(op C.igpOp, label string, xs, ys []float64) *C.igpCmd {
+	n := minint(len(xs), len(ys))
+	c := b.push(op)
+	c._type = C.igpDataType_Double
+	c.text = b.arena.string(label)
+	c.xs = unsafe.Pointer(b.arena.doubles(xs[:n]))
+	c.ys = unsafe.Pointer(b.arena.doubles(ys[:n]))
+	c.count = C.int(n)
+	c.stride = C.int(unsafe.Sizeof(float64(0)))
+	return c
+}
+
+// pushPoints appends a PlotXXX(xs, ys) command from a slice of Points.
+func (b *Batch) pushPoints(op C.igpOp, label string, points []Point) *C.igpCmd {
+	c := b.push(op)
+	c._type = C.igpDataType_Double
+	c.text = b.arena.string(label)
+	if len(points) > 0 {
+		p := b.arena.alloc(len(points)*int(unsafe.Sizeof(Point{})), int(unsafe.Alignof(Point{})))
+		copy(unsafe.Slice((*Point)(p), len(points)), points)
+		c.xs = p
+		c.ys = unsafe.Add(p, unsafe.Offsetof(Point{}.Y))
+	}
+	c.count = C.int(len(points))
+	c.stride = C.int(unsafe.Sizeof(Point{}))
+	return c
+}
+
+// SetupAxis records a SetupAxis call.
+func (b *Batch) SetupAxis(axis Axis, label string, flags AxisFlags) {
+	c := b.push(C.igpOp_SetupAxis)
+	if len(label) != 0 {
+		c.text = b.arena.string(label)
+	}
+	c.i0, c.i1 = C.int(axis), C.int(flags)
+}
+
+// SetupAxisLimits records a SetupAxisLimits call.
+func (b *Batch) SetupAxisLimits(axis Axis, vmin, vmax float64, cond Condition) {
+	c := b.push(C.igpOp_SetupAxisLimits)
+	c.i0, c.i1 = C.int(axis), C.int(cond)
+	c.d0, c.d1 = C.double(vmin), C.double(vmax)
+}
+
+// SetupAxisFormat records a SetupAxisFormat call.
+func (b *Batch) SetupAxisFormat(axis Axis, fmt string) {
+	c := b.push(C.igpOp_SetupAxisFormat)
+	c.i0 = C.int(axis)
+	c.text = b.arena.string(fmt)
+}
+
+// SetupAxesLimits records a SetupAxesLimits call.
+func (b *Batch) SetupAxesLimits(xmin, xmax, ymin, ymax float64, cond Condition) {
+	c := b.push(C.igpOp_SetupAxesLimits)
+	c.d0, c.d1, c.d2, c.d3 = C.double(xmin), C.double(xmax), C.double(ymin), C.double(ymax)
+	c.i0 = C.int(cond)
+}
+
+// SetupLegend records a SetupLegend call.
+func (b *Batch) SetupLegend(location Location, flags LegendFlags) {
+	c := b.push(C.igpOp_SetupLegend)
+	c.i0, c.i1 = C.int(location), C.int(flags)
+}
+
+// SetupFinish records a SetupFinish call.
+func (b *Batch) SetupFinish() {
+	b.push(C.igpOp_SetupFinish)
+}
+
+// PushStyleColor records a PushStyleColor call.
+func (b *Batch) PushStyleColor(id StyleCol, color imgui.Vec4) {
+	c := b.push(C.igpOp_PushStyleColor)
+	c.i0, c.c0 = C.int(id), wrapVec4(color)
+}
+
+// PopStyleColor records a PopStyleColor call.
+func (b *Batch) PopStyleColor() {
+	b.PopStyleColorV(1)
+}
+
+// PopStyleColorV records a PopStyleColorV call.
+func (b *Batch) PopStyleColorV(count int) {
+	c := b.push(C.igpOp_PopStyleColor)
+	c.i0 = C.int(count)
+}
+
+// PushStyleVarFloat records a PushStyleVarFloat call.
+func (b *Batch) PushStyleVarFloat(v StyleVar, val float32) {
+	c := b.push(C.igpOp_PushStyleVarFloat)
+	c.i0, c.d0 = C.int(v), C.double(val)
+}
+
+// PushStyleVarVec2 records a PushStyleVarVec2 call.
+func (b *Batch) PushStyleVarVec2(v StyleVar, val imgui.Vec2) {
+	c := b.push(C.igpOp_PushStyleVarVec2)
+	c.i0, c.d0, c.d1 = C.int(v), C.double(val.X), C.double(val.Y)
+}
+
+// PushStyleVarInt records a PushStyleVarInt call.
+func (b *Batch) PushStyleVarInt(v StyleVar, val int) {
+	c := b.push(C.igpOp_PushStyleVarInt)
+	c.i0, c.i1 = C.int(v), C.int(val)
+}
+
+// PopStyleVar records a PopStyleVar call.
+func (b *Batch) PopStyleVar() {
+	b.PopStyleVarV(1)
+}
+
+// PopStyleVarV records a PopStyleVarV call.
+func (b *Batch) PopStyleVarV(count int) {
+	c := b.push(C.igpOp_PopStyleVar)
+	c.i0 = C.int(count)
+}
+
+// SetNextLineStyle records a SetNextLineStyle call.
+func (b *Batch) SetNextLineStyle(color imgui.Vec4, weight float32) {
+	c := b.push(C.igpOp_SetNextLineStyle)
+	c.c0, c.d0 = wrapVec4(color), C.double(weight)
+}
+
+// SetNextFillStyle records a SetNextFillStyle call.
+func (b *Batch) SetNextFillStyle(color imgui.Vec4, alpha float32) {
+	c := b.push(C.igpOp_SetNextFillStyle)
+	c.c0, c.d0 = wrapVec4(color), C.double(alpha)
+}
+
+// SetNextMarkerStyle records a SetNextMarkerStyle call.
+func (b *Batch) SetNextMarkerStyle(marker Marker, size float32, fillColor imgui.Vec4, outlineWeight float32, outlineColor imgui.Vec4) {
+	c := b.push(C.igpOp_SetNextMarkerStyle)
+	c.i0, c.d0, c.d1 = C.int(marker), C.double(size), C.double(outlineWeight)
+	c.c0, c.c1 = wrapVec4(fillColor), wrapVec4(outlineColor)
+}
+
+// PlotLine records a PlotLine call.
+func (b *Batch) PlotLine(label string, values []float64) {
+	b.PlotLineV(label, values, 1, 0)
+}
+
+// PlotLineV records a PlotLineV call.
+func (b *Batch) PlotLineV(label string, values []float64, xscale, x0 float64) {
+	b.pushValues(C.igpOp_PlotLine, label, values, xscale, x0)
+}
+
+// PlotLineXY records a PlotLineXY call.
+func (b *Batch) PlotLineXY(label string, xs, ys []float64) {
+	b.pushXY(C.igpOp_PlotLineXY, label, xs, ys)
+}
+
+// PlotLineP records a PlotLineP call.
+func (b *Batch) PlotLineP(label string, points []Point) {
+	b.pushPoints(C.igpOp_PlotLineXY, label, points)
+}
+
+// PlotScatter records a PlotScatter call.
+func (b *Batch) PlotScatter(label string, values []float64) {
+	b.PlotScatterV(label, values, 1, 0)
+}
+
+// PlotScatterV records a PlotScatterV call.
+func (b *Batch) PlotScatterV(label string, values []float64, xscale, x0 float64) {
+	b.pushValues(C.igpOp_PlotScatter, label, values, xscale, x0)
+}
+
+// PlotScatterXY records a PlotScatterXY call.
+func (b *Batch) PlotScatterXY(label string, xs, ys []float64) {
+	b.pushXY(C.igpOp_PlotScatterXY, label, xs, ys)
+}
+
+// PlotScatterP records a PlotScatterP call.
+func (b *Batch) PlotScatterP(label string, points []Point) {
+	b.pushPoints(C.igpOp_PlotScatterXY, label, points)
+}
+
+// PlotStairs records a PlotStairs call.
+func (b *Batch) PlotStairs(label string, values []float64) {
+	b.PlotStairsV(label, values, 1, 0)
+}
+
+// PlotStairsV records a PlotStairsV call.
+func (b *Batch) PlotStairsV(label string, values []float64, xscale, x0 float64) {
+	b.pushValues(C.igpOp_PlotStairs, label, values, xscale, x0)
+}
+
+// PlotStairsXY records a PlotStairsXY call.
+func (b *Batch) PlotStairsXY(label string, xs, ys []float64) {
+	b.pushXY(C.igpOp_PlotStairsXY, label, xs, ys)
+}
+
+// PlotStairsP records a PlotStairsP call.
+func (b *Batch) PlotStairsP(label string, points []Point) {
+	b.pushPoints(C.igpOp_PlotStairsXY, label, points)
+}
+
+// PlotShadedRef records a PlotShadedRef call.
+func (b *Batch) PlotShadedRef(label string, values []float64) {
+	b.PlotShadedRefV(label, values, 0, 1, 0)
+}
+
+// PlotShadedRefV records a PlotShadedRefV call.
+func (b *Batch) PlotShadedRefV(label string, values []float64, yref, xscale, x0 float64) {
+	c := b.pushValues(C.igpOp_PlotShadedRef, label, values, xscale, x0)
+	c.d2 = C.double(yref)
+}
+
+// PlotShadedRefXY records a PlotShadedRefXY call.
+func (b *Batch) PlotShadedRefXY(label string, xs, ys []float64, yref float64) {
+	c := b.pushXY(C.igpOp_PlotShadedRefXY, label, xs, ys)
+	c.d2 = C.double(yref)
+}
+
+// PlotShadedRefP records a PlotShadedRefP call.
+func (b *Batch) PlotShadedRefP(label string, points []Point, yref float64) {
+	c := b.pushPoints(C.igpOp_PlotShadedRefXY, label, points)
+	c.d2 = C.double(yref)
+}
+
+// PlotBars records a PlotBars call.
+func (b *Batch) PlotBars(label string, values []float64) {
+	b.PlotBarsV(label, values, 0.67, 0)
+}
+
+// PlotBarsV records a PlotBarsV call.
+func (b *Batch) PlotBarsV(label string, values []float64, barWidth, x0 float64) {
+	c := b.pushValues(C.igpOp_PlotBars, label, values, 1, x0)
+	c.d2 = C.double(barWidth)
+}
+
+// PlotBarsXY records a PlotBarsXY call.
+func (b *Batch) PlotBarsXY(label string, xs, ys []float64, barWidth float64) {
+	c := b.pushXY(C.igpOp_PlotBarsXY, label, xs, ys)
+	c.d2 = C.double(barWidth)
+}
+
+// PlotBarsP records a PlotBarsP call.
+func (b *Batch) PlotBarsP(label string, points []Point, barWidth float64) {
+	c := b.pushPoints(C.igpOp_PlotBarsXY, label, points)
+	c.d2 = C.double(barWidth)
+}
+
+// PlotBarsH records a PlotBarsH call.
+func (b *Batch) PlotBarsH(label string, values []float64) {
+	b.PlotBarsHV(label, values, 0.67, 0)
+}
+
+// PlotBarsHV records a PlotBarsHV call.
+func (b *Batch) PlotBarsHV(label string, values []float64, barHeight, y0 float64) {
+	c := b.pushValues(C.igpOp_PlotBarsH, label, values, 1, y0)
+	c.d2 = C.double(barHeight)
+}
+
+// PlotBarsHXY records a PlotBarsHXY call.
+func (b *Batch) PlotBarsHXY(label string, xs, ys []float64, barHeight float64) {
+	c := b.pushXY(C.igpOp_PlotBarsHXY, label, xs, ys)
+	c.d2 = C.double(barHeight)
+}
+
+// PlotBarsHP records a PlotBarsHP call.
+func (b *Batch) PlotBarsHP(label string, points []Point, barHeight float64) {
+	c := b.pushPoints(C.igpOp_PlotBarsHXY, label, points)
+	c.d2 = C.double(barHeight)
+}
+
+// batchOpNames are the names of the calls recorded, for the validation layer.
+var batchOpNames = [C.igpOp_Count]string{
+	C.igpOp_SetupAxis:          "Batch.SetupAxis",
+	C.igpOp_SetupAxisLimits:    "Batch.SetupAxisLimits",
+	C.igpOp_SetupAxisFormat:    "Batch.SetupAxisFormat",
+	C.igpOp_SetupAxesLimits:    "Batch.SetupAxesLimits",
+	C.igpOp_SetupLegend:        "Batch.SetupLegend",
+	C.igpOp_SetupFinish:        "Batch.SetupFinish",
+	C.igpOp_PushStyleColor:     "Batch.PushStyleColor",
+	C.igpOp_PopStyleColor:      "Batch.PopStyleColorV",
+	C.igpOp_PushStyleVarFloat:  "Batch.PushStyleVarFloat",
+	C.igpOp_PushStyleVarVec2:   "Batch.PushStyleVarVec2",
+	C.igpOp_PushStyleVarInt:    "Batch.PushStyleVarInt",
+	C.igpOp_PopStyleVar:        "Batch.PopStyleVarV",
+	C.igpOp_SetNextLineStyle:   "Batch.SetNextLineStyle",
+	C.igpOp_SetNextFillStyle:   "Batch.SetNextFillStyle",
+	C.igpOp_SetNextMarkerStyle: "Batch.SetNextMarkerStyle",
+	C.igpOp_PlotLine:           "Batch.PlotLineV",
+	C.igpOp_PlotLineXY:         "Batch.PlotLineXY",
+	C.igpOp_PlotScatter:        "Batch.PlotScatterV",
+	C.igpOp_PlotScatterXY:      "Batch.PlotScatterXY",
+	C.igpOp_PlotStairs:         "Batch.PlotStairsV",
+	C.igpOp_PlotStairsXY:       "Batch.PlotStairsXY",
+	C.igpOp_PlotShadedRef:      "Batch.PlotShadedRefV",
+	C.igpOp_PlotShadedRefXY:    "Batch.PlotShadedRefXY",
+	C.igpOp_PlotBars:           "Batch.PlotBarsV",
+	C.igpOp_PlotBarsXY:         "Batch.PlotBarsXY",
+	C.igpOp_PlotBarsH:          "Batch.PlotBarsHV",
+	C.igpOp_PlotBarsHXY:        "Batch.PlotBarsHXY",
+}
+
+// validateCmd runs the validation layer on a command about to be replayed.
+func validateCmd(c *C.igpCmd) {
+	name := batchOpNames[c.op]
+	switch c.op {
+	case C.igpOp_SetupAxis, C.igpOp_SetupAxisLimits, C.igpOp_SetupAxisFormat, C.igpOp_SetupAxesLimits, C.igpOp_SetupLegend:
+		validateSetup(name)
+	case C.igpOp_PushStyleColor:
+		validatePush(stackStyleColor)
+	case C.igpOp_PopStyleColor:
+		validatePop(name, stackStyleColor, int(c.i0))
+	case C.igpOp_PushStyleVarFloat, C.igpOp_PushStyleVarVec2, C.igpOp_PushStyleVarInt:
+		validatePush(stackStyleVar)
+	case C.igpOp_PopStyleVar:
+		validatePop(name, stackStyleVar, int(c.i0))
+	case C.igpOp_SetNextLineStyle, C.igpOp_SetNextFillStyle, C.igpOp_SetNextMarkerStyle:
+	default:
+		validateLock(name)
+	}
+}
@@ -0,0 +1,137 @@
+package implot
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"unsafe"
+
+	"github.com/inkyblackness/imgui-go/v4"
+)
+
+// batchSeries is the data of the test dashboard: many small series.
+var batchSeries = func() (series [][]float64) {
+	for i := 0; i < 200; i++ {
+		s := make([]float64, 16)
+		for j := range s {
+			s[j] = math.Sin(float64(i+j)/10) + float64(i)
+		}
+		series = append(series, s)
+	}
+	return
+}()
+
+var batchXs = func() []float64 {
+	xs := make([]float64, 16)
+	for i := range xs {
+		xs[i] = float64(i)
+	}
+	return xs
+}()
+
+// plotDirect plots the test dashboard with the functions.
+func plotDirect() {
+	SetupAxis(Axis_X1, "x", AxisFlags_None)
+	SetupAxesLimits(0, 16, -1, 201, Condition_Always)
+	for i, s := range batchSeries {
+		SetNextLineStyle(imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1}, 1)
+		switch i % 4 {
+		case 0:
+			PlotLine("line", s)
+		case 1:
+			PlotScatterXY("scatter", batchXs, s)
+		case 2:
+			PlotShadedRefV("shaded", s, float64(i), 1, 0)
+		case 3:
+			PlotStairsV("stairs", s, 1, 0)
+		}
+	}
+}
+
+// plotBatch plots the test dashboard with a Batch.
+func plotBatch(b *Batch) {
+	b.SetupAxis(Axis_X1, "x", AxisFlags_None)
+	b.SetupAxesLimits(0, 16, -1, 201, Condition_Always)
+	for i, s := range batchSeries {
+		b.SetNextLineStyle(imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1}, 1)
+		switch i % 4 {
+		case 0:
+			b.PlotLine("line", s)
+		case 1:
+			b.PlotScatterXY("scatter", batchXs, s)
+		case 2:
+			b.PlotShadedRefV("shaded", s, float64(i), 1, 0)
+		case 3:
+			b.PlotStairsV("stairs", s, 1, 0)
+		}
+	}
+	b.Replay()
+}
+
+// withPlotFrames sets up fresh ImGui and ImPlot contexts, and calls #plot
+// inside a plot for #frames frames. It returns the vertices and indices
+// drawn in the last frame.
+func withPlotFrames(tb testing.TB, frames int, plot func()) []byte {
+	tb.Helper()
+	ctx := imgui.CreateContext(nil)
+	defer ctx.Destroy()
+	pctx := CreateContext()
+	defer pctx.Destroy()
+
+	io := imgui.CurrentIO()
+	io.SetDisplaySize(imgui.Vec2{X: 1280, Y: 720})
+	io.SetIniFilename("")
+	io.Fonts().TextureDataRGBA32()
+
+	var out []byte
+	for f := 0; f < frames; f++ {
+		imgui.NewFrame()
+		imgui.Begin("Window")
+		if BeginPlot("Plot", Size(imgui.Vec2{X: 1000, Y: 600})) {
+			plot()
+			EndPlot()
+		}
+		imgui.End()
+		imgui.Render()
+
+		out = out[:0]
+		for _, cl := range imgui.RenderedDrawData().CommandLists() {
+			vp, vn := cl.VertexBuffer()
+			ip, in := cl.IndexBuffer()
+			out = append(out, unsafe.Slice((*byte)(vp), vn)...)
+			out = append(out, unsafe.Slice((*byte)(ip), in)...)
+		}
+	}
+	return out
+}
+
+func TestBatchMatchesDirect(t *testing.T) {
+	direct := withPlotFrames(t, 3, plotDirect)
+	var b Batch
+	defer b.Free()
+	batch := withPlotFrames(t, 3, func() { plotBatch(&b) })
+
+	if len(direct) == 0 {
+		t.Fatal("nothing was drawn")
+	}
+	if !bytes.Equal(direct, batch) {
+		t.Errorf("the Batch drew %d bytes of vertices and indices, different from the %d bytes drawn directly", len(batch), len(direct))
+	}
+	if b.Len() != 0 {
+		t.Errorf("Replay left %d commands in the Batch", b.Len())
+	}
+}
+
+func BenchmarkDirect(b *testing.B) {
+	withPlotFrames(b, 1, plotDirect) // warm up
+	b.ResetTimer()
+	withPlotFrames(b, b.N, plotDirect)
+}
+
+func BenchmarkBatch(b *testing.B) {
+	var batch Batch
+	defer batch.Free()
+	withPlotFrames(b, 1, func() { plotBatch(&batch) })
+	b.ResetTimer()
+	withPlotFrames(b, b.N, func() { plotBatch(&batch) })
+}
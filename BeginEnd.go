@@ -1,6 +1,5 @@
 package implot
 
-// #include <stdlib.h>
 // #include "wrapper/BeginEnd.h"
 import "C"
 import (
@@ -9,8 +8,9 @@ import (
 	"github.com/inkyblackness/imgui-go/v4"
 )
 
-// BeginPlot starts a 2D plotting context with only the title specified.
-// It calls BeginPlotV(title, Vec2{-1, 0}, Flags_None).
+// BeginPlot starts a 2D plotting context with the title and optional parameters
+// (Size and WithFlags) specified.
+// Without options, it is the same as BeginPlotV(title, Vec2{-1, 0}, Flags_None).
 //
 // If this function returns true, then EndPlot() MUST be called! You can do:
 //     if igwrap.BeginPlot(...) {
@@ -26,8 +26,9 @@ import (
 //  - size is the **frame** size of the plot widget, not the plot area.
 //    The default size of plots (i.e. when ImVec2(0,0)) can be modified
 //    in your ImPlotStyle.
-func BeginPlot(title string) bool {
-	return BeginPlotV(title, imgui.Vec2{X: -1, Y: 0}, Flags_None)
+func BeginPlot(title string, opts ...Option) bool {
+	o := applyOptions(opts)
+	return BeginPlotV(title, o.size, o.flags)
 }
 
 // BeginPlotV starts a 2D plotting context with all the parameters specified.
@@ -47,9 +48,11 @@ func BeginPlot(title string) bool {
 //    The default size of plots (i.e. when ImVec2(0,0)) can be modified
 //    in your ImPlotStyle.
 func BeginPlotV(title string, size imgui.Vec2, flags Flags) bool {
-	ctitle := C.CString(title)
-	defer C.free(unsafe.Pointer(ctitle))
-	return bool(C.igpBeginPlot(ctitle, wrapVec2(size), C.igpFlags(flags)))
+	validateBeginPlot("BeginPlot")
+	current().arena.reset()
+	ok := bool(C.igpBeginPlot(wrapString(title), wrapVec2(size), C.igpFlags(flags)))
+	validateBeganPlot(ok)
+	return ok
 }
 
 // EndPlot marks the end of an active plot.
@@ -57,21 +60,16 @@ func BeginPlotV(title string, size imgui.Vec2, flags Flags) bool {
 // Only call EndPlot() if BeginPlot() returns true! Typically called at the end
 // of an if statement conditioned on BeginPlot(). See example above.
 func EndPlot() {
+	validateEndPlot("EndPlot")
 	C.igpEndPlot()
 
-	// Discard temp data
-	// from Setup.go:69
-	for k := range axisFormatCb {
-		delete(axisFormatCb, k)
-	}
-	for _, f := range axisEndPlotCb {
-		f()
-	}
-	axisEndPlotCb = axisEndPlotCb[0:0]
+	// Discard temp data of the current context
+	current().endPlot()
 }
 
-// BeginSubplots starts a subdivided plotting context with onle the required parameters.
-// It calls BeginSubplotsV(title, rows, cols, Vec2{-1, 0}, SubplotFlags_None, nil, nil).
+// BeginSubplots starts a subdivided plotting context with the required parameters,
+// and optional ones (Size, WithSubplotFlags, RowRatios and ColRatios).
+// Without options, it is the same as BeginSubplotsV(title, rows, cols, Vec2{-1, 0}, SubplotFlags_None, nil, nil).
 //
 // If the function returns true, EndSubplots() MUST be called! Call BeginPlot/EndPlot
 // AT MOST [rows*cols] times in between the begining and end of the subplot context.
@@ -115,8 +113,9 @@ func EndPlot() {
 //  - The #size parameter of _BeginPlot_ (see above) is ignored when inside of a
 //    subplot context. The actual size of the subplot will be based on the
 //    #size value you pass to _BeginSubplots_ and #row/#col_ratios if provided.
-func BeginSubplots(title string, rows, cols int) bool {
-	return BeginSubplotsV(title, rows, cols, imgui.Vec2{X: -1, Y: 0}, SubplotFlags_None, nil, nil)
+func BeginSubplots(title string, rows, cols int, opts ...Option) bool {
+	o := applyOptions(opts)
+	return BeginSubplotsV(title, rows, cols, o.size, o.subplotFlags, o.rowRatios, o.colRatios)
 }
 
 // BeginSubplotsV starts a subdivided plotting context with all parameters.
@@ -172,10 +171,11 @@ func BeginSubplotsV(title string, rows, cols int, size imgui.Vec2, flags Subplot
 		cf = (*C.float)(unsafe.Pointer(&colRatios[0]))
 	}
 
-	ctitle := C.CString(title)
-	defer C.free(unsafe.Pointer(ctitle))
-
-	return bool(C.igpBeginSubplots(ctitle, C.int(rows), C.int(cols), wrapVec2(size), C.igpSubplotFlags(flags), rf, cf))
+	validateBeginSubplots("BeginSubplots", rows, cols)
+	current().arena.reset()
+	ok := bool(C.igpBeginSubplots(wrapString(title), C.int(rows), C.int(cols), wrapVec2(size), C.igpSubplotFlags(flags), rf, cf))
+	validateBeganSubplots(ok, rows, cols)
+	return ok
 }
 
 // EndSubplots marks the end of a subdivided plotting area.
@@ -183,5 +183,6 @@ func BeginSubplotsV(title string, rows, cols int, size imgui.Vec2, flags Subplot
 // Only call EndSubplots() if BeginSubplots() returns true! Typically called at the end
 // of an if statement conditioned on BeginSublots(). See example above.
 func EndSubplots() {
+	validateEndSubplots("EndSubplots")
 	C.igpEndSubplots()
 }
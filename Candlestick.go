@@ -0,0 +1,87 @@
+package implot
+
+// #include "wrapper/Candlestick.h"
+import "C"
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/inkyblackness/imgui-go/v4"
+)
+
+// Default colors of the candles going up and down
+var (
+	defaultBullColor = imgui.Vec4{X: 0, Y: 0.75, Z: 0.3, W: 1}
+	defaultBearColor = imgui.Vec4{X: 0.85, Y: 0.15, Z: 0.15, W: 1}
+)
+
+// BullColor sets the color of the candles of PlotCandlestick closing higher
+// than they opened (default=green).
+func BullColor(color imgui.Vec4) Option {
+	return func(o *options) { o.bullColor = &color }
+}
+
+// BearColor sets the color of the candles of PlotCandlestick closing lower
+// than they opened (default=red).
+func BearColor(color imgui.Vec4) Option {
+	return func(o *options) { o.bearColor = &color }
+}
+
+// Volume adds volume bars under the candles of PlotCandlestick, one for every
+// candle. They take up #height of the plot height (e.g. 0.2) at its bottom,
+// scaled to the largest visible volume, and are not part of the fit.
+func Volume(volumes []float64, height float32) Option {
+	return func(o *options) { o.volumes, o.volumeHeight = volumes, height }
+}
+
+// NoTooltip hides the tooltip of PlotCandlestick shown when hovering a candle.
+func NoTooltip() Option {
+	return func(o *options) { o.noTooltip = true }
+}
+
+// PlotCandlestick plots open-high-low-close candles at the sorted #xs
+// (UNIX seconds with AxisFlags_Time, or any other unit). Hovering a candle
+// shows its values in a tooltip.
+//
+// The optional parameters BarWidth (the fraction of the smallest X spacing
+// a candle takes up, or of 1 without any spacing, a day on a Time axis),
+// BullColor, BearColor, Volume and NoTooltip apply.
+//
+// It returns an error if the slices (including the volumes) have different
+// lengths, or if the plot has scales set by SetupAxisScale (ErrScaleUnsupported).
+func PlotCandlestick(label string, xs, opens, closes, lows, highs []float64, opts ...Option) error {
+	o := applyOptions(opts)
+	lens := []int{len(xs), len(opens), len(closes), len(lows), len(highs)}
+	if o.volumes != nil {
+		lens = append(lens, len(o.volumes))
+	}
+	if err := checkLengths("PlotCandlestick", lens...); err != nil {
+		return err
+	}
+	validateLock("PlotCandlestick")
+	if current().scaled {
+		return fmt.Errorf("implot: PlotCandlestick: %w", ErrScaleUnsupported)
+	}
+
+	bull, bear := defaultBullColor, defaultBearColor
+	if o.bullColor != nil {
+		bull = *o.bullColor
+	}
+	if o.bearColor != nil {
+		bear = *o.bearColor
+	}
+	C.igpPlotCandlestick(
+		wrapString(label),
+		doublePtr(xs), doublePtr(opens), doublePtr(closes), doublePtr(lows), doublePtr(highs), doublePtr(o.volumes),
+		C.int(len(xs)), C.double(o.barWidth), wrapVec4(bull), wrapVec4(bear), C.float(o.volumeHeight), C.bool(!o.noTooltip),
+	)
+	return nil
+}
+
+// doublePtr returns the address of the first element of #s, or nil if it is empty.
+func doublePtr(s []float64) *C.double {
+	if len(s) == 0 {
+		return nil
+	}
+	return (*C.double)(unsafe.Pointer(&s[0]))
+}
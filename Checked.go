@@ -0,0 +1,263 @@
+package implot
+
+// #include "wrapper/Plot.h"
+import "C"
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// Plotting empty slices is fine everywhere: nothing is drawn, but the
+// legend entry of the item is kept, so it does not flicker in and out
+// while a stream of data is starting.
+//
+// The XY functions with more than one slice silently plot only the
+// shortest length of them. The PlotXXXE variants below check the lengths
+// instead, and return an error wrapping ErrLengthMismatch without plotting
+// anything if they do not match. They also take Options, including
+// SkipNonFinite to drop NaN and Inf values.
+
+// ErrLengthMismatch is wrapped by the errors of the PlotXXXE functions
+// when the given slices have different lengths.
+var ErrLengthMismatch = errors.New("mismatched data lengths")
+
+// checkLengths returns an error if not all the lengths are the same.
+func checkLengths(call string, lens ...int) error {
+	for _, l := range lens[1:] {
+		if l != lens[0] {
+			return fmt.Errorf("implot: %s: %w %v", call, ErrLengthMismatch, lens)
+		}
+	}
+	return nil
+}
+
+// isFloat returns if T can hold NaN or Inf.
+func isFloat[T Number]() bool {
+	typ := dataTypeOf[T]()
+	return typ == C.igpDataType_Float || typ == C.igpDataType_Double
+}
+
+// gatherPoints copies the points into the scratch buffer of the current
+// context, applying the offset and stride options, and returns it.
+//
+// If xs is nil, the X coordinates are x0 + i*xscale, like PlotXXXV.
+func gatherPoints[T Number](xs, ys []T, o *options) []Point {
+	n := len(ys)
+	if xs != nil {
+		n = minint(len(xs), n)
+	}
+	st := o.stride
+	if st < 1 {
+		st = 1
+	}
+	count := (n + st - 1) / st
+
+	s := current()
+	pts := s.scratch[:0]
+	if count > 0 {
+		off := (o.offset%count + count) % count
+		for i := 0; i < count; i++ {
+			k := ((off + i) % count) * st
+			if xs == nil {
+				pts = append(pts, Point{X: o.x0 + float64(i)*o.xscale, Y: float64(ys[k])})
+			} else {
+				pts = append(pts, Point{X: float64(xs[k]), Y: float64(ys[k])})
+			}
+		}
+	}
+	s.scratch = pts
+	return pts
+}
+
+// finitePoints is gatherPoints, with the scales of the X1/Y1 axes applied
+// and only the finite points kept.
+func finitePoints[T Number](xs, ys []T, o *options) []Point {
+	s := current()
+	s.scratch = scaledPoints(gatherPoints(xs, ys, o))
+	return s.scratch
+}
+
+// wrapXYSliceOpts is wrapXYSlice with the offset, stride and SkipNonFinite options applied.
+func wrapXYSliceOpts[T Number](xs, ys []T, o *options) (typ C.igpDataType, xp, yp unsafe.Pointer, count, offset, stride C.int) {
+	if usePoints[T](o) {
+		xp, yp, count, stride = wrapPointSlice(finitePoints(xs, ys, o))
+		return C.igpDataType_Double, xp, yp, count, 0, stride
+	}
+	typ, xp, yp, count, stride = wrapXYSlice(xs, ys)
+	count, offset, stride = o.strided(count, stride)
+	return
+}
+
+// PlotLineXYE plots a standard 2D line plot from slices of X/Y coords,
+// with optional parameters: Offset, Stride and SkipNonFinite.
+//
+// It returns an error if len(xs) != len(ys).
+func PlotLineXYE[T Number](label string, xs, ys []T, opts ...Option) error {
+	if err := checkLengths("PlotLineXYE", len(xs), len(ys)); err != nil {
+		return err
+	}
+	o := applyOptions(opts)
+	validateLock("PlotLineXYE")
+	typ, xp, yp, count, offset, stride := wrapXYSliceOpts(xs, ys, &o)
+	C.igpPlotLineXY(typ, wrapString(label), xp, yp, count, offset, stride)
+	return nil
+}
+
+// PlotScatterXYE plots a standard 2D scatter plot from slices of X/Y coords,
+// with optional parameters: Offset, Stride and SkipNonFinite.
+//
+// It returns an error if len(xs) != len(ys).
+func PlotScatterXYE[T Number](label string, xs, ys []T, opts ...Option) error {
+	if err := checkLengths("PlotScatterXYE", len(xs), len(ys)); err != nil {
+		return err
+	}
+	o := applyOptions(opts)
+	validateLock("PlotScatterXYE")
+	typ, xp, yp, count, offset, stride := wrapXYSliceOpts(xs, ys, &o)
+	C.igpPlotScatterXY(typ, wrapString(label), xp, yp, count, offset, stride)
+	return nil
+}
+
+// PlotStairsXYE plots a stairstep graph from slices of X/Y coords,
+// with optional parameters: Offset, Stride and SkipNonFinite.
+//
+// It returns an error if len(xs) != len(ys).
+func PlotStairsXYE[T Number](label string, xs, ys []T, opts ...Option) error {
+	if err := checkLengths("PlotStairsXYE", len(xs), len(ys)); err != nil {
+		return err
+	}
+	o := applyOptions(opts)
+	validateLock("PlotStairsXYE")
+	typ, xp, yp, count, offset, stride := wrapXYSliceOpts(xs, ys, &o)
+	C.igpPlotStairsXY(typ, wrapString(label), xp, yp, count, offset, stride)
+	return nil
+}
+
+// PlotShadedRefXYE plots a shaded (filled) region between a line and a horizontal reference,
+// with optional parameters: Offset, Stride and SkipNonFinite.
+//
+// It returns an error if len(xs) != len(ys).
+func PlotShadedRefXYE[T Number](label string, xs, ys []T, yref float64, opts ...Option) error {
+	if err := checkLengths("PlotShadedRefXYE", len(xs), len(ys)); err != nil {
+		return err
+	}
+	o := applyOptions(opts)
+	validateLock("PlotShadedRefXYE")
+	typ, xp, yp, count, offset, stride := wrapXYSliceOpts(xs, ys, &o)
+	C.igpPlotShadedRefXY(typ, wrapString(label), xp, yp, count, C.double(ScaleValue(Axis_Y1, yref)), offset, stride)
+	return nil
+}
+
+// PlotShadedLinesE plots a shaded (filled) region between two lines, without the lines themselves,
+// with optional parameters: XScale, X0, Offset and Stride.
+//
+// It returns an error if len(vs0) != len(vs1).
+func PlotShadedLinesE[T Number](label string, vs0, vs1 []T, opts ...Option) error {
+	if err := checkLengths("PlotShadedLinesE", len(vs0), len(vs1)); err != nil {
+		return err
+	}
+	o := applyOptions(opts)
+	validateLock("PlotShadedLinesE")
+	if current().scaled {
+		xp, yp1, yp2, count, stride := wrapShadedScaled(nil, vs0, vs1, &o)
+		C.igpPlotShadedLinesXY(C.igpDataType_Double, wrapString(label), xp, yp1, yp2, count, 0, stride)
+		return nil
+	}
+	typ, vp0, vp1, count, stride := wrapXYSlice(vs0, vs1)
+	count, offset, stride := o.strided(count, stride)
+	C.igpPlotShadedLines(typ, wrapString(label), vp0, vp1, count, C.double(o.xscale), C.double(o.x0), offset, stride)
+	return nil
+}
+
+// PlotShadedLinesXYE plots a shaded (filled) region between two lines, without the lines themselves,
+// with optional parameters: Offset and Stride.
+//
+// It returns an error if the lengths of xs, ys1 and ys2 are not the same.
+func PlotShadedLinesXYE[T Number](label string, xs, ys1, ys2 []T, opts ...Option) error {
+	if err := checkLengths("PlotShadedLinesXYE", len(xs), len(ys1), len(ys2)); err != nil {
+		return err
+	}
+	o := applyOptions(opts)
+	validateLock("PlotShadedLinesXYE")
+	if current().scaled {
+		xp, yp1, yp2, count, stride := wrapShadedScaled(xs, ys1, ys2, &o)
+		C.igpPlotShadedLinesXY(C.igpDataType_Double, wrapString(label), xp, yp1, yp2, count, 0, stride)
+		return nil
+	}
+	typ, xp, yp1, count, stride := wrapXYSlice(xs, ys1)
+	_, _, yp2, _, _ := wrapXYSlice(xs, ys2)
+	count, offset, stride := o.strided(count, stride)
+	C.igpPlotShadedLinesXY(typ, wrapString(label), xp, yp1, yp2, count, offset, stride)
+	return nil
+}
+
+// PlotBarsXYE plots a vertical bar graph, with bars each taking up a
+// fraction of the available width. #barWidth should be in (0, 1].
+// Optional parameters are Offset, Stride and SkipNonFinite.
+//
+// It returns an error if len(vx) != len(vy).
+func PlotBarsXYE[T Number](label string, vx, vy []T, barWidth float64, opts ...Option) error {
+	if err := checkLengths("PlotBarsXYE", len(vx), len(vy)); err != nil {
+		return err
+	}
+	o := applyOptions(opts)
+	validateLock("PlotBarsXYE")
+	typ, xp, yp, count, offset, stride := wrapXYSliceOpts(vx, vy, &o)
+	C.igpPlotBarsXY(typ, wrapString(label), xp, yp, count, C.double(barWidth), offset, stride)
+	return nil
+}
+
+// PlotBarsHXYE plots a horizontal bar graph, with bars each taking up a
+// fraction of the available height. #barHeight should be in (0, 1].
+// Optional parameters are Offset, Stride and SkipNonFinite.
+//
+// It returns an error if len(vx) != len(vy).
+func PlotBarsHXYE[T Number](label string, vx, vy []T, barHeight float64, opts ...Option) error {
+	if err := checkLengths("PlotBarsHXYE", len(vx), len(vy)); err != nil {
+		return err
+	}
+	o := applyOptions(opts)
+	validateLock("PlotBarsHXYE")
+	typ, xp, yp, count, offset, stride := wrapXYSliceOpts(vx, vy, &o)
+	C.igpPlotBarsHXY(typ, wrapString(label), xp, yp, count, C.double(barHeight), offset, stride)
+	return nil
+}
+
+// checkBarGroups returns an error if the bar groups are ragged,
+// or if there are not as many item labels as items.
+func checkBarGroups[T Number](call string, itemLabels []string, values [][]T) error {
+	if len(itemLabels) != len(values) {
+		return fmt.Errorf("implot: %s: %w: %d item labels, %d items", call, ErrLengthMismatch, len(itemLabels), len(values))
+	}
+	for i := range values {
+		if len(values[i]) != len(values[0]) {
+			return fmt.Errorf("implot: %s: %w: item 0 has %d groups, item %d has %d", call, ErrLengthMismatch, len(values[0]), i, len(values[i]))
+		}
+	}
+	return nil
+}
+
+// PlotBarGroupsE plots a group of vertical bars. See PlotBarGroups.
+//
+// It returns an error if len(itemLabels) != len(values), or if the items
+// do not have the same number of groups.
+func PlotBarGroupsE[T Number](itemLabels []string, values [][]T, groupWidth, x0 float64, flags BarGroupsFlags) error {
+	if err := checkBarGroups("PlotBarGroupsE", itemLabels, values); err != nil {
+		return err
+	}
+	PlotBarGroups(itemLabels, values, groupWidth, x0, flags)
+	return nil
+}
+
+// PlotBarGroupsHE plots a group of horizontal bars. See PlotBarGroupsH.
+//
+// It returns an error if len(itemLabels) != len(values), or if the items
+// do not have the same number of groups.
+func PlotBarGroupsHE[T Number](itemLabels []string, values [][]T, groupWidth, y0 float64, flags BarGroupsFlags) error {
+	if err := checkBarGroups("PlotBarGroupsHE", itemLabels, values); err != nil {
+		return err
+	}
+	PlotBarGroupsH(itemLabels, values, groupWidth, y0, flags)
+	return nil
+}
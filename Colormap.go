@@ -0,0 +1,91 @@
+package implot
+
+// #include <stdlib.h>
+// #include "wrapper/Colormap.h"
+import "C"
+import (
+	"unsafe"
+
+	"github.com/inkyblackness/imgui-go/v4"
+)
+
+// Colormap_Auto is used in the colormap utils to use the current colormap.
+const Colormap_Auto Colormap = -1
+
+// GetColormapCount returns the number of available colormaps
+// (i.e. the built-in + user-added count).
+func GetColormapCount() int {
+	return int(C.igpGetColormapCount())
+}
+
+// GetColormapName returns the name for a colormap given an index.
+// Returns "" if index is invalid.
+func GetColormapName(cmap Colormap) string {
+	return C.GoString(C.igpGetColormapName(C.igpColormap(cmap)))
+}
+
+// GetColormapIndex returns an index number for a colormap given a valid name.
+// Returns -1 if name is invalid.
+func GetColormapIndex(name string) Colormap {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	return Colormap(C.igpGetColormapIndex(cname))
+}
+
+// PushColormap temporarily switches to one of the built-in or user-added colormaps.
+//
+// You MUST call a pop for every push, otherwise you will leak memory!
+func PushColormap(cmap Colormap) {
+	validatePush(stackColormap)
+	C.igpPushColormap(C.igpColormap(cmap))
+}
+
+// PushColormapName temporarily switches to a colormap by name.
+// Use built-in names such as "Default", "Deep", "Jet", etc.
+//
+// You MUST call a pop for every push, otherwise you will leak memory!
+func PushColormapName(name string) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	validatePush(stackColormap)
+	C.igpPushColormapName(cname)
+}
+
+// PopColormap undoes one temporary colormap modification.
+// It calls PopColormapV(1).
+func PopColormap() {
+	validatePop("PopColormap", stackColormap, 1)
+	C.igpPopColormap(1)
+}
+
+// PopColormapV undoes #count temporary colormap modifications.
+func PopColormapV(count int) {
+	validatePop("PopColormapV", stackColormap, count)
+	C.igpPopColormap(C.int(count))
+}
+
+// NextColormapColor returns the next color from the current colormap and
+// advances the colormap for the current plot.
+//
+// You need to call this between Begin/EndPlot!
+func NextColormapColor() imgui.Vec4 {
+	return unwrapVec4(C.igpNextColormapColor())
+}
+
+// GetColormapSize returns the size of a colormap.
+// Use Colormap_Auto for the current colormap.
+func GetColormapSize(cmap Colormap) int {
+	return int(C.igpGetColormapSize(C.igpColormap(cmap)))
+}
+
+// GetColormapColor returns a color from a colormap given an index >= 0
+// (modulo will be performed). Use Colormap_Auto for the current colormap.
+func GetColormapColor(idx int, cmap Colormap) imgui.Vec4 {
+	return unwrapVec4(C.igpGetColormapColor(C.int(idx), C.igpColormap(cmap)))
+}
+
+// SampleColormap samples a color from a colormap given t between 0 and 1.
+// Use Colormap_Auto for the current colormap.
+func SampleColormap(t float32, cmap Colormap) imgui.Vec4 {
+	return unwrapVec4(C.igpSampleColormap(C.float(t), C.igpColormap(cmap)))
+}
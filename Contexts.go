@@ -10,14 +10,121 @@ import (
 )
 
 // Context specifies a scope; a global state for ImPlot.
+//
+// Each Context also has its own Go-side state: the callbacks and temporary
+// memory of the plot being built, which are discarded at its EndPlot.
+// Using more than one Context (e.g. one per OS window) does not mix them up.
 type Context struct {
 	handle C.igpContext
 }
 
+// contextState is the Go-side state of a Context.
+type contextState struct {
+	// Axis formatters, keyed by handle. Cleaned after every EndPlot().
+	formatters map[uintptr]struct {
+		fmt      Formatter
+		userData interface{}
+	}
+	// Getters active in a PlotXXXG() call, keyed by handle.
+	getters map[uintptr]struct {
+		get      DataGetter
+		userData interface{}
+	}
+	// Callbacks after every EndPlot(), for cleaning.
+	endPlotCb []func()
+	// Last handle given out. Handle 0 is never used.
+	lastHandle uintptr
+	// C memory for the strings passed to ImPlot. Reset at every BeginPlot and EndPlot.
+	arena stringArena
+	// Tick buffers reused by SetupAxisLocator.
+	tickValues []float64
+	tickLabels []string
+	// Axis scales of the current plot (see SetupAxisScale), and if X1 or Y1 has one.
+	scales [Axis_Count]*Transform
+	scaled bool
+	// Results of the statistical overlays (see Stats.go), kept across plots.
+	stats      map[statsKey]*statsEntry
+	statsClock uint64
+	// Scratch buffer for filtered data (see SkipNonFinite), reused by every plot.
+	scratch []Point
+
+	// State tracked by the validation layer (see Validate.go)
+	debug debugState
+}
+
+var (
+	// Go-side states of every Context.
+	contextStates = make(map[C.igpContext]*contextState)
+	// State of the current Context; nil if unknown yet.
+	currentState *contextState
+)
+
+// stateOf returns the Go-side state of a context, creating it if needed.
+func stateOf(handle C.igpContext) *contextState {
+	s, ok := contextStates[handle]
+	if !ok {
+		s = &contextState{
+			formatters: make(map[uintptr]struct {
+				fmt      Formatter
+				userData interface{}
+			}),
+			getters: make(map[uintptr]struct {
+				get      DataGetter
+				userData interface{}
+			}),
+		}
+		contextStates[handle] = s
+	}
+	return s
+}
+
+// current returns the Go-side state of the current context.
+// It panics with ErrNoContext if there is none.
+func current() *contextState {
+	if currentState == nil {
+		raw := C.igpCurrentContext()
+		if raw == nil {
+			panic(ErrNoContext)
+		}
+		currentState = stateOf(raw)
+	}
+	return currentState
+}
+
+// newHandle returns a new callback handle, unique within the context.
+func (s *contextState) newHandle() uintptr {
+	s.lastHandle++
+	return s.lastHandle
+}
+
+// addEndPlotCb adds a function to be called after the next EndPlot().
+func addEndPlotCb(f func()) {
+	s := current()
+	s.endPlotCb = append(s.endPlotCb, f)
+}
+
+// endPlot discards the temp data of the plot just ended.
+func (s *contextState) endPlot() {
+	s.arena.reset()
+	s.scales, s.scaled = [Axis_Count]*Transform{}, false
+	for k := range s.formatters {
+		delete(s.formatters, k)
+	}
+	for _, f := range s.endPlotCb {
+		f()
+	}
+	s.endPlotCb = s.endPlotCb[0:0]
+}
+
 // CreateContext creates a new ImPlot context.
 // It should be called right after imgui.CreateContext().
+//
+// If there is no current context, the new context is set as current.
 func CreateContext() *Context {
-	return &Context{handle: C.igpCreateContext()}
+	handle := C.igpCreateContext()
+	stateOf(handle)
+	currentState = nil
+	return &Context{handle: handle}
 }
 
 // ErrNoContext is used when no context is current.
@@ -43,6 +150,11 @@ var ErrContextDestroyed = errors.New("context is destroyed")
 func (c *Context) Destroy() {
 	if c.handle != nil {
 		C.igpDestroyContext(c.handle)
+		if s, ok := contextStates[c.handle]; ok {
+			s.arena.free()
+		}
+		delete(contextStates, c.handle)
+		currentState = nil
 		c.handle = nil
 	}
 }
@@ -53,6 +165,7 @@ func (c *Context) SetCurrent() error {
 		return ErrContextDestroyed
 	}
 	C.igpSetCurrentContext(c.handle)
+	currentState = stateOf(c.handle)
 	return nil
 }
 
@@ -0,0 +1,99 @@
+package implot
+
+// #include "wrapper/Plot.h"
+import "C"
+
+// CullRange returns the window [lo, hi) of the sorted #xs visible in the X
+// range of the current plot, with one more point on each side, so that the
+// line reaches the plot edges. It locks the setup.
+//
+// When the X axis is being fitted (e.g. in the first frame, unless
+// AxisFlags_NoInitialFit is set), it returns the whole slice, so the plot
+// fits to all of the data.
+func CullRange[T Number](xs []T) (lo, hi int) {
+	validateLock("CullRange")
+	xmin, xmax, _, fitting := lineView()
+	if fitting {
+		return 0, len(xs)
+	}
+	return visibleRange(xs, xmin, xmax)
+}
+
+// cullXY returns the visible windows of #xs and #ys, with the offset option cleared.
+// The windows start at a multiple of the stride option, so the same samples
+// are plotted while panning.
+func cullXY[T Number](call string, xs, ys []T, o *options) ([]T, []T, error) {
+	if err := checkLengths(call, len(xs), len(ys)); err != nil {
+		return nil, nil, err
+	}
+	validateLock(call)
+	lo, hi := CullRange(xs)
+	lo -= lo % o.stride
+	o.offset = 0
+	return xs[lo:hi], ys[lo:hi], nil
+}
+
+// PlotLineXYCulled plots a standard 2D line plot from slices of sorted X/Y
+// coords, passing only the visible part (see CullRange) to ImPlot.
+// The optional parameters Stride and SkipNonFinite apply.
+//
+// It returns an error if len(xs) != len(ys).
+func PlotLineXYCulled[T Number](label string, xs, ys []T, opts ...Option) error {
+	o := applyOptions(opts)
+	xs, ys, err := cullXY("PlotLineXYCulled", xs, ys, &o)
+	if err != nil {
+		return err
+	}
+	typ, xp, yp, count, offset, stride := wrapXYSliceOpts(xs, ys, &o)
+	C.igpPlotLineXY(typ, wrapString(label), xp, yp, count, offset, stride)
+	return nil
+}
+
+// PlotScatterXYCulled plots a standard 2D scatter plot from slices of sorted X/Y
+// coords, passing only the visible part (see CullRange) to ImPlot.
+// The optional parameters Stride and SkipNonFinite apply.
+//
+// It returns an error if len(xs) != len(ys).
+func PlotScatterXYCulled[T Number](label string, xs, ys []T, opts ...Option) error {
+	o := applyOptions(opts)
+	xs, ys, err := cullXY("PlotScatterXYCulled", xs, ys, &o)
+	if err != nil {
+		return err
+	}
+	typ, xp, yp, count, offset, stride := wrapXYSliceOpts(xs, ys, &o)
+	C.igpPlotScatterXY(typ, wrapString(label), xp, yp, count, offset, stride)
+	return nil
+}
+
+// PlotStairsXYCulled plots a stairstep graph from slices of sorted X/Y
+// coords, passing only the visible part (see CullRange) to ImPlot.
+// The optional parameters Stride and SkipNonFinite apply.
+//
+// It returns an error if len(xs) != len(ys).
+func PlotStairsXYCulled[T Number](label string, xs, ys []T, opts ...Option) error {
+	o := applyOptions(opts)
+	xs, ys, err := cullXY("PlotStairsXYCulled", xs, ys, &o)
+	if err != nil {
+		return err
+	}
+	typ, xp, yp, count, offset, stride := wrapXYSliceOpts(xs, ys, &o)
+	C.igpPlotStairsXY(typ, wrapString(label), xp, yp, count, offset, stride)
+	return nil
+}
+
+// PlotShadedRefXYCulled plots a shaded (filled) region between a line and a
+// horizontal reference from slices of sorted X/Y coords, passing only the
+// visible part (see CullRange) to ImPlot.
+// The optional parameters Stride and SkipNonFinite apply.
+//
+// It returns an error if len(xs) != len(ys).
+func PlotShadedRefXYCulled[T Number](label string, xs, ys []T, yref float64, opts ...Option) error {
+	o := applyOptions(opts)
+	xs, ys, err := cullXY("PlotShadedRefXYCulled", xs, ys, &o)
+	if err != nil {
+		return err
+	}
+	typ, xp, yp, count, offset, stride := wrapXYSliceOpts(xs, ys, &o)
+	C.igpPlotShadedRefXY(typ, wrapString(label), xp, yp, count, C.double(ScaleValue(Axis_Y1, yref)), offset, stride)
+	return nil
+}
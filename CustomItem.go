@@ -0,0 +1,144 @@
+package implot
+
+// #include "wrapper/CustomItem.h"
+import "C"
+import "github.com/inkyblackness/imgui-go/v4"
+
+// New kinds of items can be made in Go, drawn with the draw list of the plot
+// like the items of ImPlot:
+//
+//	if implot.BeginItem("My Item", implot.StyleCol_Line) {
+//		if implot.FitThisFrame() {
+//			implot.FitPoint(implot.Point{X: 0, Y: 0})
+//			implot.FitPoint(implot.Point{X: 1, Y: 1})
+//		}
+//		color := imgui.PackedColorFromVec4(implot.GetItemStyle().LineColor)
+//		implot.GetPlotDrawList().AddLine(implot.PlotToPixels(implot.Point{X: 0, Y: 0}), implot.PlotToPixels(implot.Point{X: 1, Y: 1}), color)
+//		implot.EndItem()
+//	}
+//
+// The item gets a legend entry, which can hide it, and a color from the
+// colormap. Or implement CustomItem and use PlotCustom.
+
+// ItemStyle is the style of the current item, with the SetNextXXXStyle
+// calls and the style of the plot applied.
+type ItemStyle struct {
+	LineColor, FillColor                imgui.Vec4 // FillColor has FillAlpha applied
+	MarkerOutlineColor, MarkerFillColor imgui.Vec4
+	LineWeight                          float32
+	Marker                              Marker
+	MarkerSize, MarkerWeight, FillAlpha float32
+}
+
+// BeginItem begins a custom item, registering it in the legend.
+// Its color is taken from the style color #recolorFrom if it is set
+// (by SetNextXXXStyle or PushStyleColor), or from the colormap.
+// Use -1 to always take it from the colormap.
+//
+// If it returns true, draw the item and call EndItem. If it returns false,
+// the item is hidden, and EndItem must not be called.
+func BeginItem(label string, recolorFrom StyleCol) bool {
+	validateLock("BeginItem")
+	return bool(C.igpBeginItem(wrapString(label), C.igpStyleCol(recolorFrom)))
+}
+
+// EndItem ends a custom item. Only call it if BeginItem returned true.
+func EndItem() {
+	validateLock("EndItem")
+	C.igpEndItem()
+}
+
+// FitThisFrame returns true if the plot is being fitted this frame,
+// and the items should report their extents with FitPoint.
+func FitThisFrame() bool {
+	validateLock("FitThisFrame")
+	return bool(C.igpFitThisFrame())
+}
+
+// FitPoint extends the fit of the current plot to include a point.
+func FitPoint(p Point) {
+	validateLock("FitPoint")
+	C.igpFitPoint(p.wrap())
+}
+
+// FitPointX extends the fit of the X axis of the current plot to include a value.
+func FitPointX(x float64) {
+	validateLock("FitPointX")
+	C.igpFitPointX(C.double(x))
+}
+
+// FitPointY extends the fit of the Y axis of the current plot to include a value.
+func FitPointY(y float64) {
+	validateLock("FitPointY")
+	C.igpFitPointY(C.double(y))
+}
+
+// PlotToPixels converts a point in plot coordinates to screen pixels.
+func PlotToPixels(p Point) imgui.Vec2 {
+	validateLock("PlotToPixels")
+	return unwrapVec2(C.igpPlotToPixels(p.wrap()))
+}
+
+// PixelsToPlot converts a position in screen pixels to plot coordinates.
+func PixelsToPlot(pix imgui.Vec2) Point {
+	validateLock("PixelsToPlot")
+	p := C.igpPixelsToPlot(wrapVec2(pix))
+	return Point{X: float64(p.x), Y: float64(p.y)}
+}
+
+// GetPlotDrawList returns the draw list of the current plot.
+// Between BeginItem and EndItem, it is clipped to the plot area.
+func GetPlotDrawList() imgui.DrawList {
+	validateLock("GetPlotDrawList")
+	return imgui.DrawList(C.igpGetPlotDrawList())
+}
+
+// GetCurrentItemColor returns the color of the current item, which is its legend icon color.
+func GetCurrentItemColor() imgui.Vec4 {
+	validateLock("GetCurrentItemColor")
+	return unwrapVec4(C.igpGetCurrentItemColor())
+}
+
+// SetCurrentItemColor sets the color of the current item, which is its legend icon color.
+func SetCurrentItemColor(color imgui.Vec4) {
+	validateLock("SetCurrentItemColor")
+	C.igpSetCurrentItemColor(wrapVec4(color))
+}
+
+// GetItemStyle returns the style of the current item.
+func GetItemStyle() ItemStyle {
+	validateLock("GetItemStyle")
+	s := C.igpGetItemStyle()
+	return ItemStyle{
+		LineColor:          unwrapVec4(s.line),
+		FillColor:          unwrapVec4(s.fill),
+		MarkerOutlineColor: unwrapVec4(s.marker_outline),
+		MarkerFillColor:    unwrapVec4(s.marker_fill),
+		LineWeight:         float32(s.line_weight),
+		Marker:             Marker(s.marker),
+		MarkerSize:         float32(s.marker_size),
+		MarkerWeight:       float32(s.marker_weight),
+		FillAlpha:          float32(s.fill_alpha),
+	}
+}
+
+// CustomItem is a custom kind of item, plotted with PlotCustom.
+type CustomItem interface {
+	// Fit reports the extents of the item with FitPoint.
+	Fit()
+	// Draw draws the item with the draw list of the plot, in the given style.
+	Draw(drawList imgui.DrawList, style ItemStyle)
+}
+
+// PlotCustom plots a CustomItem, with its color taken from the line color
+// like PlotLine.
+func PlotCustom(label string, item CustomItem) {
+	if !BeginItem(label, StyleCol_Line) {
+		return
+	}
+	if FitThisFrame() {
+		item.Fit()
+	}
+	item.Draw(GetPlotDrawList(), GetItemStyle())
+	EndItem()
+}
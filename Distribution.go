@@ -0,0 +1,277 @@
+package implot
+
+import (
+	"math"
+	"sort"
+
+	"github.com/inkyblackness/imgui-go/v4"
+)
+
+// PlotBoxPlot and PlotViolin compare the distributions of groups of values,
+// one item per group. Like PlotBarGroups, the groups are placed at
+// x0, x0+1, ... x0+N-1. Label them with SetupAxisTickValues.
+//
+// They are custom items (see BeginItem) with their color taken from the
+// fill color like PlotBars, and the statistics are cached like PlotBands.
+// The scales set by SetupAxisScale are applied to the shapes drawn.
+
+// Bandwidth selection rules of PlotViolin, see Bandwidth
+const (
+	Bandwidth_Silverman = 0  // Silverman's rule of thumb, 0.9 * min(sd, IQR/1.34) * n^(-1/5)
+	Bandwidth_Scott     = -1 // Scott's rule, 1.06 * sd * n^(-1/5)
+)
+
+// violinPoints is the number of points the density of PlotViolin is evaluated at.
+const violinPoints = 64
+
+// Horizontal makes PlotBoxPlot and PlotViolin horizontal, with the groups
+// placed along the Y axis.
+func Horizontal() Option {
+	return func(o *options) { o.horizontal = true }
+}
+
+// WhiskersMinMax makes the whiskers of PlotBoxPlot reach the minimum and the
+// maximum, instead of the most extreme values within 1.5 IQR of the box
+// (Tukey's rule, with the values outside shown as outliers).
+func WhiskersMinMax() Option {
+	return func(o *options) { o.whiskersMinMax = true }
+}
+
+// Notched adds notches to the boxes of PlotBoxPlot, around the 95% confidence
+// interval of the median: +/- 1.57 IQR / sqrt(n).
+func Notched() Option {
+	return func(o *options) { o.notched = true }
+}
+
+// Bandwidth sets the bandwidth of the Gaussian kernel density estimate of
+// PlotViolin: a positive value, or Bandwidth_Silverman (default) or Bandwidth_Scott.
+func Bandwidth(bandwidth float64) Option {
+	return func(o *options) { o.bandwidth = bandwidth }
+}
+
+// distPoint returns the point at #pos along the groups and #value along the
+// values, swapped if horizontal, in plot space (with the scales applied).
+func distPoint(o *options, pos, value float64) Point {
+	if o.horizontal {
+		pos, value = value, pos
+	}
+	return Point{X: ScaleValue(Axis_X1, pos), Y: ScaleValue(Axis_Y1, value)}
+}
+
+// quantile returns the #q-th quantile of the sorted #s, interpolated linearly.
+func quantile(s []float64, q float64) float64 {
+	f := q * float64(len(s)-1)
+	i := int(f)
+	if i+1 >= len(s) {
+		return s[len(s)-1]
+	}
+	return s[i] + (f-float64(i))*(s[i+1]-s[i])
+}
+
+// sortedGroup returns a sorted copy of #group without the NaNs, cached.
+func sortedGroup(group []float64) []float64 {
+	return statsCache(statsSorted, group, group, 0, 0, func(e *statsEntry) {
+		e.y1 = make([]float64, 0, len(group))
+		for _, v := range group {
+			if !math.IsNaN(v) {
+				e.y1 = append(e.y1, v)
+			}
+		}
+		sort.Float64s(e.y1)
+	}).y1
+}
+
+// PlotBoxPlot plots a box plot of every group of values: a box from the
+// first to the third quartile with a line at the median, and whiskers.
+//
+// The optional parameters X0, BarWidth (the width of the boxes), Horizontal,
+// WhiskersMinMax and Notched apply.
+//
+// Item count N = Min(len(labels), len(groups)).
+func PlotBoxPlot(labels []string, groups [][]float64, opts ...Option) {
+	o := applyOptions(opts)
+	validateLock("PlotBoxPlot")
+	for i := 0; i < len(labels) && i < len(groups); i++ {
+		plotBox(labels[i], sortedGroup(groups[i]), o.x0+float64(i), &o)
+	}
+}
+
+func plotBox(label string, s []float64, pos float64, o *options) {
+	if !BeginItem(label, StyleCol_Fill) {
+		return
+	}
+	defer EndItem()
+	if len(s) == 0 {
+		return
+	}
+
+	q1, med, q3 := quantile(s, 0.25), quantile(s, 0.5), quantile(s, 0.75)
+	lo, hi := s[0], s[len(s)-1]
+	if !o.whiskersMinMax {
+		iqr := q3 - q1
+		i := sort.SearchFloat64s(s, q1-1.5*iqr)
+		j := sort.Search(len(s), func(k int) bool { return s[k] > q3+1.5*iqr }) - 1
+		lo, hi = s[i], s[j]
+	}
+	left, right := pos-o.barWidth/2, pos+o.barWidth/2
+
+	if FitThisFrame() {
+		FitPoint(distPoint(o, left, s[0]))
+		FitPoint(distPoint(o, right, s[len(s)-1]))
+	}
+
+	style := GetItemStyle()
+	dl := GetPlotDrawList()
+	fill, line := imgui.PackedColorFromVec4(style.FillColor), imgui.PackedColorFromVec4(style.LineColor)
+	px := func(pos, value float64) imgui.Vec2 { return PlotToPixels(distPoint(o, pos, value)) }
+	seg := func(p0, v0, p1, v1 float64) { dl.AddLineV(px(p0, v0), px(p1, v1), line, style.LineWeight) }
+	quad := func(p0, p1, v0, p2, p3, v1 float64) {
+		// The quad from [p0, p1] at v0 to [p2, p3] at v1
+		a, b, c, d := px(p0, v0), px(p1, v0), px(p3, v1), px(p2, v1)
+		dl.AddTriangleFilled(a, b, c, fill)
+		dl.AddTriangleFilled(a, c, d, fill)
+		seg(p0, v0, p2, v1)
+		seg(p1, v0, p3, v1)
+	}
+
+	// The box, with the sides going in to the median in a notch
+	if o.notched {
+		ci := 1.57 * (q3 - q1) / math.Sqrt(float64(len(s)))
+		nlo, nhi := math.Max(q1, med-ci), math.Min(q3, med+ci)
+		in := o.barWidth / 4
+		quad(left, right, q1, left, right, nlo)
+		quad(left, right, nlo, left+in, right-in, med)
+		quad(left+in, right-in, med, left, right, nhi)
+		quad(left, right, nhi, left, right, q3)
+		seg(left+in, med, right-in, med)
+	} else {
+		quad(left, right, q1, left, right, q3)
+		seg(left, med, right, med)
+	}
+	seg(left, q1, right, q1)
+	seg(left, q3, right, q3)
+
+	// Whiskers with caps
+	seg(pos, q1, pos, lo)
+	seg(pos, q3, pos, hi)
+	seg(pos-o.barWidth/4, lo, pos+o.barWidth/4, lo)
+	seg(pos-o.barWidth/4, hi, pos+o.barWidth/4, hi)
+
+	// Outliers
+	markerFill := imgui.PackedColorFromVec4(style.MarkerFillColor)
+	markerLine := imgui.PackedColorFromVec4(style.MarkerOutlineColor)
+	for _, v := range s {
+		if v < lo || v > hi {
+			c := px(pos, v)
+			dl.AddCircleFilled(c, style.MarkerSize, markerFill)
+			dl.AddCircleV(c, style.MarkerSize, markerLine, 0, style.MarkerWeight)
+		}
+	}
+}
+
+// kdeBandwidth returns the bandwidth of the sorted #s by a rule.
+func kdeBandwidth(s []float64, rule float64) float64 {
+	if rule > 0 {
+		return rule
+	}
+	var mean, m2 float64
+	for i, v := range s {
+		d := v - mean
+		mean += d / float64(i+1)
+		m2 += d * (v - mean)
+	}
+	sd := math.Sqrt(m2 / math.Max(1, float64(len(s)-1)))
+	n := math.Pow(float64(len(s)), -0.2)
+
+	var h float64
+	if rule == Bandwidth_Scott {
+		h = 1.06 * sd * n
+	} else {
+		spread := sd
+		if iqr := (quantile(s, 0.75) - quantile(s, 0.25)) / 1.34; iqr > 0 && iqr < spread {
+			spread = iqr
+		}
+		h = 0.9 * spread * n
+	}
+	if !(h > 0) {
+		// All the values are the same
+		h = 1e-3 * math.Max(1, math.Abs(mean))
+	}
+	return h
+}
+
+// PlotViolin plots a violin plot of every group of values: its Gaussian kernel
+// density estimate mirrored on both sides, cut 2 bandwidths beyond the extreme
+// values, with a line from the first to the third quartile and a dot at the median,
+// in the marker colors of the item (see SetNextMarkerStyle).
+//
+// The optional parameters X0, BarWidth (the width at the highest density),
+// Horizontal and Bandwidth apply.
+//
+// Item count N = Min(len(labels), len(groups)).
+func PlotViolin(labels []string, groups [][]float64, opts ...Option) {
+	o := applyOptions(opts)
+	validateLock("PlotViolin")
+	for i := 0; i < len(labels) && i < len(groups); i++ {
+		plotViolin(labels[i], groups[i], o.x0+float64(i), &o)
+	}
+}
+
+func plotViolin(label string, group []float64, pos float64, o *options) {
+	if !BeginItem(label, StyleCol_Fill) {
+		return
+	}
+	defer EndItem()
+	s := sortedGroup(group)
+	if len(s) == 0 {
+		return
+	}
+
+	// The density at violinPoints values, scaled to a maximum of 1
+	e := statsCache(statsViolin, group, group, 0, o.bandwidth, func(e *statsEntry) {
+		h := kdeBandwidth(s, o.bandwidth)
+		from, to := s[0]-2*h, s[len(s)-1]+2*h
+		e.xs, e.y1 = make([]float64, violinPoints), make([]float64, violinPoints)
+		var max float64
+		for i := range e.xs {
+			x := from + (to-from)*float64(i)/(violinPoints-1)
+			var d float64
+			// Only the values within 6 bandwidths matter
+			for j := sort.SearchFloat64s(s, x-6*h); j < len(s) && s[j] <= x+6*h; j++ {
+				u := (x - s[j]) / h
+				d += math.Exp(-u * u / 2)
+			}
+			e.xs[i], e.y1[i] = x, d
+			max = math.Max(max, d)
+		}
+		for i := range e.y1 {
+			e.y1[i] /= max
+		}
+	})
+	half := o.barWidth / 2
+
+	if FitThisFrame() {
+		FitPoint(distPoint(o, pos-half, e.xs[0]))
+		FitPoint(distPoint(o, pos+half, e.xs[len(e.xs)-1]))
+	}
+
+	style := GetItemStyle()
+	dl := GetPlotDrawList()
+	fill, line := imgui.PackedColorFromVec4(style.FillColor), imgui.PackedColorFromVec4(style.LineColor)
+	px := func(pos, value float64) imgui.Vec2 { return PlotToPixels(distPoint(o, pos, value)) }
+	for i := 1; i < len(e.xs); i++ {
+		w0, w1 := e.y1[i-1]*half, e.y1[i]*half
+		a, b := px(pos-w0, e.xs[i-1]), px(pos+w0, e.xs[i-1])
+		c, d := px(pos+w1, e.xs[i]), px(pos-w1, e.xs[i])
+		dl.AddTriangleFilled(a, b, c, fill)
+		dl.AddTriangleFilled(a, c, d, fill)
+		dl.AddLineV(a, d, line, style.LineWeight)
+		dl.AddLineV(b, c, line, style.LineWeight)
+	}
+
+	// Quartiles and median
+	dl.AddLineV(px(pos, quantile(s, 0.25)), px(pos, quantile(s, 0.75)), line, 3*style.LineWeight)
+	c := px(pos, quantile(s, 0.5))
+	dl.AddCircleFilled(c, style.MarkerSize, imgui.PackedColorFromVec4(style.MarkerFillColor))
+	dl.AddCircleV(c, style.MarkerSize, imgui.PackedColorFromVec4(style.MarkerOutlineColor), 0, style.MarkerWeight)
+}
@@ -0,0 +1,194 @@
+package implot
+
+// #include "wrapper/Downsample.h"
+// #include "wrapper/Plot.h"
+import "C"
+import (
+	"math"
+	"sort"
+)
+
+// PlotLineLTTB and PlotLineMinMax plot huge series (millions of points) by
+// sending ImPlot only the visible part of the data, downsampled to a few
+// points per pixel of the plot. The X coordinates must be sorted.
+//
+// When the X axis is being fitted (e.g. by double-clicking the plot),
+// the whole series is downsampled, so the plot fits to all of it.
+//
+// The downsampled points go to a buffer reused by every plot, and are
+// plotted as float64. The scales set by SetupAxisScale are applied.
+
+// lineView returns the X range of the data visible in the current plot and
+// the pixel width of the plot. It locks the setup.
+func lineView() (xmin, xmax float64, pixels float32, fitting bool) {
+	var vmin, vmax C.double
+	var px C.float
+	var fit C.bool
+	C.igpGetAxisView(C.igpAxis(Axis_X1), &vmin, &vmax, &px, &fit)
+
+	xmin, xmax = UnscaleValue(Axis_X1, float64(vmin)), UnscaleValue(Axis_X1, float64(vmax))
+	if xmin > xmax {
+		xmin, xmax = xmax, xmin
+	}
+	pixels = float32(px)
+	if pixels <= 0 {
+		// First frame, the plot is not laid out yet
+		pixels = 1920
+	}
+	return xmin, xmax, pixels, bool(fit)
+}
+
+// visibleRange returns the indices [lo, hi) of the sorted #xs in [xmin, xmax],
+// with one more point on each side, so that the line reaches the plot edges.
+func visibleRange[T Number](xs []T, xmin, xmax float64) (lo, hi int) {
+	lo = sort.Search(len(xs), func(i int) bool { return float64(xs[i]) >= xmin })
+	hi = sort.Search(len(xs), func(i int) bool { return float64(xs[i]) > xmax })
+	if lo > 0 {
+		lo--
+	}
+	if hi < len(xs) {
+		hi++
+	}
+	return
+}
+
+// lttb appends to #pts the points of #xs and #ys downsampled by the
+// Largest-Triangle-Three-Buckets algorithm to #threshold points.
+func lttb[T Number](pts []Point, xs, ys []T, threshold int) []Point {
+	n := len(xs)
+	if threshold < 3 || n <= threshold {
+		for i := range xs {
+			pts = append(pts, Point{X: float64(xs[i]), Y: float64(ys[i])})
+		}
+		return pts
+	}
+
+	// The first and last points are always kept, the rest are split into threshold-2 buckets
+	every := float64(n-2) / float64(threshold-2)
+	a := 0
+	pts = append(pts, Point{X: float64(xs[0]), Y: float64(ys[0])})
+	for b := 0; b < threshold-2; b++ {
+		// Average of the next bucket, the third point of the triangle
+		next0, next1 := int(float64(b+1)*every)+1, int(float64(b+2)*every)+1
+		if next1 > n {
+			next1 = n
+		}
+		var avgX, avgY float64
+		for i := next0; i < next1; i++ {
+			avgX += float64(xs[i])
+			avgY += float64(ys[i])
+		}
+		if next1 > next0 {
+			avgX /= float64(next1 - next0)
+			avgY /= float64(next1 - next0)
+		}
+
+		// The point in this bucket with the largest triangle
+		ax, ay := float64(xs[a]), float64(ys[a])
+		maxArea, maxAt := -1.0, int(float64(b)*every)+1
+		for i := int(float64(b)*every) + 1; i < next0; i++ {
+			area := math.Abs((ax-avgX)*(float64(ys[i])-ay) - (ax-float64(xs[i]))*(avgY-ay))
+			if area > maxArea {
+				maxArea, maxAt = area, i
+			}
+		}
+		pts = append(pts, Point{X: float64(xs[maxAt]), Y: float64(ys[maxAt])})
+		a = maxAt
+	}
+	return append(pts, Point{X: float64(xs[n-1]), Y: float64(ys[n-1])})
+}
+
+// minMax appends to #pts the points of #xs and #ys downsampled to the
+// minimum and maximum of each of #buckets buckets of X in [xmin, xmax],
+// in their original order.
+func minMax[T Number](pts []Point, xs, ys []T, xmin, xmax float64, buckets int) []Point {
+	if len(xs) <= 2*buckets || !(xmax > xmin) {
+		for i := range xs {
+			pts = append(pts, Point{X: float64(xs[i]), Y: float64(ys[i])})
+		}
+		return pts
+	}
+
+	scale := float64(buckets) / (xmax - xmin)
+	flush := func(lo, hi int) {
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		pts = append(pts, Point{X: float64(xs[lo]), Y: float64(ys[lo])})
+		if hi != lo {
+			pts = append(pts, Point{X: float64(xs[hi]), Y: float64(ys[hi])})
+		}
+	}
+
+	cur, lo, hi := math.Floor((float64(xs[0])-xmin)*scale), 0, 0
+	for i := 1; i < len(xs); i++ {
+		if b := math.Floor((float64(xs[i]) - xmin) * scale); b != cur {
+			flush(lo, hi)
+			cur, lo, hi = b, i, i
+			continue
+		}
+		if ys[i] < ys[lo] {
+			lo = i
+		}
+		if ys[i] > ys[hi] {
+			hi = i
+		}
+	}
+	flush(lo, hi)
+	return pts
+}
+
+// PlotLineLTTB plots a line from huge slices of sorted X/Y coords,
+// downsampled with the Largest-Triangle-Three-Buckets algorithm, which keeps
+// the shape of the line. The optional parameter PointsPerPixel applies.
+//
+// It returns an error if len(xs) != len(ys).
+func PlotLineLTTB[T Number](label string, xs, ys []T, opts ...Option) error {
+	if err := checkLengths("PlotLineLTTB", len(xs), len(ys)); err != nil {
+		return err
+	}
+	o := applyOptions(opts)
+	validateLock("PlotLineLTTB")
+
+	xmin, xmax, pixels, fitting := lineView()
+	lo, hi := 0, len(xs)
+	if !fitting {
+		lo, hi = visibleRange(xs, xmin, xmax)
+	}
+
+	s := current()
+	s.scratch = lttb(s.scratch[:0], xs[lo:hi], ys[lo:hi], int(float64(pixels)*o.pointsPerPixel))
+	s.scratch = scaledPoints(s.scratch)
+	xp, yp, count, stride := wrapPointSlice(s.scratch)
+	C.igpPlotLineXY(C.igpDataType_Double, wrapString(label), xp, yp, count, 0, stride)
+	return nil
+}
+
+// PlotLineMinMax plots a line from huge slices of sorted X/Y coords,
+// downsampled to the minimum and maximum of every pixel column, which keeps
+// every peak visible.
+//
+// It returns an error if len(xs) != len(ys).
+func PlotLineMinMax[T Number](label string, xs, ys []T) error {
+	if err := checkLengths("PlotLineMinMax", len(xs), len(ys)); err != nil {
+		return err
+	}
+	validateLock("PlotLineMinMax")
+
+	xmin, xmax, pixels, fitting := lineView()
+	lo, hi := 0, len(xs)
+	if fitting {
+		if len(xs) > 0 {
+			xmin, xmax = float64(xs[0]), float64(xs[len(xs)-1])
+		}
+	} else {
+		lo, hi = visibleRange(xs, xmin, xmax)
+	}
+
+	s := current()
+	s.scratch = minMax(s.scratch[:0], xs[lo:hi], ys[lo:hi], xmin, xmax, int(pixels))
+	s.scratch = scaledPoints(s.scratch)
+	xp, yp, count, stride := wrapPointSlice(s.scratch)
+	C.igpPlotLineXY(C.igpDataType_Double, wrapString(label), xp, yp, count, 0, stride)
+	return nil
+}
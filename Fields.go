@@ -0,0 +1,250 @@
+package implot
+
+// #include "wrapper/Plot.h"
+import "C"
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+// The PlotXXXFields functions plot two fields of a slice of structs in place,
+// using the stride parameter of ImPlot, just like PlotXXXP does for Points.
+// For example:
+//
+//     type Sample struct {
+//         T, Voltage, Current float64
+//     }
+//     var samples []Sample
+//     ...
+//     PlotLineFields("Voltage", samples, "T", "Voltage")
+//     PlotLineFields("Current", samples, "T", "Current")
+//
+// The fields are named as in Go; fields of nested (non-pointer) structs can
+// be named with a dot, e.g. "Pos.X". Both fields must be of the same Number
+// type. If they are not, the functions panic.
+//
+// The fields are looked up once for every struct type and field pair,
+// and cached for later calls.
+//
+// cgo does not allow passing memory with Go pointers in it to C, so if the
+// struct has any pointers (including strings, slices, maps and interfaces),
+// the two fields are copied into a buffer reused by every plot, and plotted
+// as float64. So are they if the plot has scales (see SetupAxisScale).
+
+// structFieldsKey identifies a cached field pair lookup.
+type structFieldsKey struct {
+	typ    reflect.Type
+	xf, yf string
+}
+
+// structFields is a cached field pair lookup.
+type structFields struct {
+	typ        C.igpDataType
+	kind       reflect.Kind
+	xoff, yoff uintptr
+	pointers   bool // the struct has pointers, and must be copied
+}
+
+var structFieldsCache = make(map[structFieldsKey]structFields)
+
+// kindDataType returns the igpDataType of a Number kind.
+func kindDataType(k reflect.Kind) (C.igpDataType, bool) {
+	switch k {
+	case reflect.Float32:
+		return C.igpDataType_Float, true
+	case reflect.Float64:
+		return C.igpDataType_Double, true
+	case reflect.Int8:
+		return C.igpDataType_S8, true
+	case reflect.Uint8:
+		return C.igpDataType_U8, true
+	case reflect.Int16:
+		return C.igpDataType_S16, true
+	case reflect.Uint16:
+		return C.igpDataType_U16, true
+	case reflect.Int32:
+		return C.igpDataType_S32, true
+	case reflect.Uint32:
+		return C.igpDataType_U32, true
+	case reflect.Int64:
+		return C.igpDataType_S64, true
+	case reflect.Uint64:
+		return C.igpDataType_U64, true
+	case reflect.Int:
+		return dataTypeOf[int](), true
+	case reflect.Uint:
+		return dataTypeOf[uint](), true
+	}
+	return 0, false
+}
+
+// hasPointers returns if values of #t contain Go pointers.
+func hasPointers(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasPointers(t.Field(i).Type) {
+				return true
+			}
+		}
+		return false
+	case reflect.Array:
+		return t.Len() > 0 && hasPointers(t.Elem())
+	case reflect.Pointer, reflect.UnsafePointer, reflect.String, reflect.Slice,
+		reflect.Map, reflect.Chan, reflect.Func, reflect.Interface:
+		return true
+	}
+	return false
+}
+
+// fieldValue reads a number of #kind at #p as float64.
+func fieldValue(p unsafe.Pointer, kind reflect.Kind) float64 {
+	switch kind {
+	case reflect.Float32:
+		return float64(*(*float32)(p))
+	case reflect.Float64:
+		return *(*float64)(p)
+	case reflect.Int8:
+		return float64(*(*int8)(p))
+	case reflect.Uint8:
+		return float64(*(*uint8)(p))
+	case reflect.Int16:
+		return float64(*(*int16)(p))
+	case reflect.Uint16:
+		return float64(*(*uint16)(p))
+	case reflect.Int32:
+		return float64(*(*int32)(p))
+	case reflect.Uint32:
+		return float64(*(*uint32)(p))
+	case reflect.Int64:
+		return float64(*(*int64)(p))
+	case reflect.Uint64:
+		return float64(*(*uint64)(p))
+	case reflect.Int:
+		return float64(*(*int)(p))
+	case reflect.Uint:
+		return float64(*(*uint)(p))
+	}
+	return 0
+}
+
+// lookupField finds the offset and type of a (dotted) field path in a struct type.
+func lookupField(t reflect.Type, path string) (offset uintptr, ft reflect.Type) {
+	ft = t
+	for _, name := range strings.Split(path, ".") {
+		if ft.Kind() != reflect.Struct {
+			panic(fmt.Errorf("PlotXXXFields: field %q of %s: %s is not a struct", path, t, ft))
+		}
+		f, ok := ft.FieldByName(name)
+		if !ok {
+			panic(fmt.Errorf("PlotXXXFields: %s has no field %q", t, path))
+		}
+		// Promoted fields of embedded structs have longer index paths
+		cur := ft
+		for _, i := range f.Index {
+			sf := cur.Field(i)
+			offset += sf.Offset
+			cur = sf.Type
+			if cur.Kind() == reflect.Pointer && sf.Name != f.Name {
+				panic(fmt.Errorf("PlotXXXFields: field %q of %s is behind an embedded pointer", path, t))
+			}
+		}
+		ft = f.Type
+	}
+	return
+}
+
+// lookupStructFields returns the cached lookup of a field pair of S.
+func lookupStructFields[S any](xField, yField string) structFields {
+	key := structFieldsKey{typ: reflect.TypeOf((*S)(nil)).Elem(), xf: xField, yf: yField}
+	if f, ok := structFieldsCache[key]; ok {
+		return f
+	}
+
+	xoff, xt := lookupField(key.typ, xField)
+	yoff, yt := lookupField(key.typ, yField)
+	if xt != yt {
+		panic(fmt.Errorf("PlotXXXFields: fields %q (%s) and %q (%s) of %s have different types", xField, xt, yField, yt, key.typ))
+	}
+	typ, ok := kindDataType(xt.Kind())
+	if !ok {
+		panic(fmt.Errorf("PlotXXXFields: fields %q and %q of %s are not numbers (%s)", xField, yField, key.typ, xt))
+	}
+
+	f := structFields{typ: typ, kind: xt.Kind(), xoff: xoff, yoff: yoff, pointers: hasPointers(key.typ)}
+	structFieldsCache[key] = f
+	return f
+}
+
+// wrapStructSlice returns the X/Y pointers of the given fields in a slice of structs,
+// or in the scratch buffer if the structs have pointers or the plot has scales.
+func wrapStructSlice[S any](slice []S, xField, yField string) (typ C.igpDataType, xp, yp unsafe.Pointer, count, stride C.int) {
+	f := lookupStructFields[S](xField, yField)
+	if s := current(); f.pointers || s.scaled {
+		pts := s.scratch[:0]
+		for i := range slice {
+			base := unsafe.Pointer(&slice[i])
+			pts = append(pts, Point{X: fieldValue(unsafe.Add(base, f.xoff), f.kind), Y: fieldValue(unsafe.Add(base, f.yoff), f.kind)})
+		}
+		if s.scaled {
+			pts = scaledPoints(pts)
+		}
+		s.scratch = pts
+		xp, yp, count, stride = wrapPointSlice(pts)
+		return C.igpDataType_Double, xp, yp, count, stride
+	}
+
+	typ = f.typ
+	stride = C.int(unsafe.Sizeof(*new(S)))
+	if len(slice) == 0 {
+		return
+	}
+	base := unsafe.Pointer(&slice[0])
+	return typ, unsafe.Add(base, f.xoff), unsafe.Add(base, f.yoff), C.int(len(slice)), stride
+}
+
+// PlotLineFields plots a standard 2D line plot from two fields of a slice of structs.
+func PlotLineFields[S any](label string, data []S, xField, yField string) {
+	validateLock("PlotLineFields")
+	typ, xp, yp, count, stride := wrapStructSlice(data, xField, yField)
+	C.igpPlotLineXY(typ, wrapString(label), xp, yp, count, 0, stride)
+}
+
+// PlotScatterFields plots a standard 2D scatter plot from two fields of a slice of structs.
+//
+// Default marker is ImPlotMarker_Circle.
+func PlotScatterFields[S any](label string, data []S, xField, yField string) {
+	validateLock("PlotScatterFields")
+	typ, xp, yp, count, stride := wrapStructSlice(data, xField, yField)
+	C.igpPlotScatterXY(typ, wrapString(label), xp, yp, count, 0, stride)
+}
+
+// PlotStairsFields plots a stairstep graph from two fields of a slice of structs.
+//
+// The y value is continued constantly from every x position,
+// i.e. the interval [x[i], x[i+1]) has the value y[i].
+func PlotStairsFields[S any](label string, data []S, xField, yField string) {
+	validateLock("PlotStairsFields")
+	typ, xp, yp, count, stride := wrapStructSlice(data, xField, yField)
+	C.igpPlotStairsXY(typ, wrapString(label), xp, yp, count, 0, stride)
+}
+
+// PlotShadedRefFields plots a shaded (filled) region between a line and a horizontal reference,
+// from two fields of a slice of structs.
+//
+// Set yref to +/-INFINITY for infinite fill extents.
+func PlotShadedRefFields[S any](label string, data []S, xField, yField string, yref float64) {
+	validateLock("PlotShadedRefFields")
+	typ, xp, yp, count, stride := wrapStructSlice(data, xField, yField)
+	C.igpPlotShadedRefXY(typ, wrapString(label), xp, yp, count, C.double(ScaleValue(Axis_Y1, yref)), 0, stride)
+}
+
+// PlotBarsFields plots a vertical bar graph from two fields of a slice of structs,
+// with bars each taking up a fraction of the available width. #barWidth should be in (0, 1].
+func PlotBarsFields[S any](label string, data []S, xField, yField string, barWidth float64) {
+	validateLock("PlotBarsFields")
+	typ, xp, yp, count, stride := wrapStructSlice(data, xField, yField)
+	C.igpPlotBarsXY(typ, wrapString(label), xp, yp, count, C.double(barWidth), 0, stride)
+}
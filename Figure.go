@@ -0,0 +1,346 @@
+package implot
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/inkyblackness/imgui-go/v4"
+)
+
+// Figure is a declarative description of a plot, for configuration-driven UIs.
+//
+// Render issues BeginPlotV, the Setup* calls, the Plot* calls and EndPlot
+// in the correct order every frame. A Figure round-trips through JSON,
+// so it can be stored and edited without recompiling:
+//
+//	{
+//	    "title": "Temperature",
+//	    "size": {"X": -1, "Y": 300},
+//	    "axes": [
+//	        {"axis": 0, "label": "Time (s)"},
+//	        {"axis": 3, "label": "°C", "limits": {"Min": 0, "Max": 100}, "format": "%.0f"}
+//	    ],
+//	    "legend": {"location": 5},
+//	    "series": [
+//	        {"kind": "line", "label": "Inlet", "x": [0, 1, 2], "y": [20, 22, 25]},
+//	        {"kind": "bars", "label": "Heater", "y": [1, 0, 1], "barWidth": 0.5,
+//	         "style": {"fillAlpha": 0.5}}
+//	    ]
+//	}
+//
+// Flags and enums (Axis, Location, Marker, ...) are stored as their numeric values.
+// In the X/Y values and YRef of the series, NaN is stored as null, and the
+// infinities as the strings "+Inf" and "-Inf", as JSON numbers cannot hold them.
+type Figure struct {
+	Title  string        `json:"title"`
+	Size   imgui.Vec2    `json:"size"`
+	Flags  Flags         `json:"flags,omitempty"`
+	Axes   []FigureAxis  `json:"axes,omitempty"`
+	Legend *FigureLegend `json:"legend,omitempty"`
+	Series []Series      `json:"series,omitempty"`
+}
+
+// FigureAxis describes the setup of one axis of a Figure.
+//
+// Axes not listed in a Figure keep their defaults (X1 and Y1 enabled).
+type FigureAxis struct {
+	Axis   Axis      `json:"axis"`
+	Label  string    `json:"label,omitempty"`
+	Flags  AxisFlags `json:"flags,omitempty"`
+	Limits *Range    `json:"limits,omitempty"`
+	// Condition of the Limits. The zero value (Condition_None) is taken as
+	// Condition_Once; use Condition_Always to lock the limits.
+	LimitsCond Condition `json:"limitsCond,omitempty"`
+	// Format specifier of the tick labels, e.g. "%.2f". Empty for the default.
+	Format string `json:"format,omitempty"`
+}
+
+// FigureLegend describes the legend of a Figure.
+type FigureLegend struct {
+	Location Location    `json:"location"`
+	Flags    LegendFlags `json:"flags,omitempty"`
+}
+
+// SeriesKind is the kind of plot item of a Series.
+type SeriesKind string
+
+const (
+	SeriesKind_Line      SeriesKind = "line"    // PlotLine
+	SeriesKind_Scatter   SeriesKind = "scatter" // PlotScatter
+	SeriesKind_Stairs    SeriesKind = "stairs"  // PlotStairs
+	SeriesKind_ShadedRef SeriesKind = "shaded"  // PlotShadedRef, filled down to YRef
+	SeriesKind_Bars      SeriesKind = "bars"    // PlotBars, with BarWidth
+	SeriesKind_BarsH     SeriesKind = "barsh"   // PlotBarsH, with BarWidth as the bar height
+)
+
+// Series is one plot item of a Figure.
+//
+// If X is empty, Y is plotted against its indices (X0 + i*XScale, XScale
+// defaulting to 1). Otherwise X and Y must have the same length.
+type Series struct {
+	Kind  SeriesKind `json:"kind"`
+	Label string     `json:"label"`
+
+	X SeriesData `json:"x,omitempty"`
+	Y SeriesData `json:"y"`
+
+	XScale   float64 `json:"xscale,omitempty"`
+	X0       float64 `json:"x0,omitempty"`
+	YRef     float64 `json:"yref,omitempty"`     // SeriesKind_ShadedRef only
+	BarWidth float64 `json:"barWidth,omitempty"` // SeriesKind_Bars/BarsH only, defaults to 0.67
+
+	Style *SeriesStyle `json:"style,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, storing a non-finite YRef like SeriesData.
+func (s Series) MarshalJSON() ([]byte, error) {
+	type plain Series
+	return json.Marshal(struct {
+		plain
+		YRef jsonFloat `json:"yref,omitempty"`
+	}{plain(s), jsonFloat(s.YRef)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Series) UnmarshalJSON(data []byte) error {
+	type plain Series
+	v := struct {
+		*plain
+		YRef jsonFloat `json:"yref,omitempty"`
+	}{plain: (*plain)(s)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	s.YRef = float64(v.YRef)
+	return nil
+}
+
+// SeriesData is the X or Y values of a Series.
+//
+// In JSON, NaN (e.g. a gap, see SkipNonFinite) is stored as null, and the
+// infinities as the strings "+Inf" and "-Inf".
+type SeriesData []float64
+
+// MarshalJSON implements json.Marshaler.
+func (d SeriesData) MarshalJSON() ([]byte, error) {
+	if d == nil {
+		return []byte("null"), nil
+	}
+	b := []byte{'['}
+	for i, v := range d {
+		if i != 0 {
+			b = append(b, ',')
+		}
+		b = jsonFloat(v).append(b)
+	}
+	return append(b, ']'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *SeriesData) UnmarshalJSON(data []byte) error {
+	var vs []jsonFloat
+	if err := json.Unmarshal(data, &vs); err != nil {
+		return err
+	}
+	if vs == nil {
+		*d = nil
+		return nil
+	}
+	*d = make(SeriesData, len(vs))
+	for i, v := range vs {
+		(*d)[i] = float64(v)
+	}
+	return nil
+}
+
+// jsonFloat is a float64 stored in JSON like the values of SeriesData.
+type jsonFloat float64
+
+func (f jsonFloat) append(b []byte) []byte {
+	switch v := float64(f); {
+	case math.IsNaN(v):
+		return append(b, "null"...)
+	case math.IsInf(v, 1):
+		return append(b, `"+Inf"`...)
+	case math.IsInf(v, -1):
+		return append(b, `"-Inf"`...)
+	default:
+		return strconv.AppendFloat(b, v, 'g', -1, 64)
+	}
+}
+
+func (f jsonFloat) MarshalJSON() ([]byte, error) {
+	return f.append(nil), nil
+}
+
+func (f *jsonFloat) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		*f = jsonFloat(math.NaN())
+		return nil
+	}
+	if uq, err := strconv.Unquote(s); err == nil {
+		s = uq
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("implot: invalid Figure value %s", data)
+	}
+	*f = jsonFloat(v)
+	return nil
+}
+
+// SeriesStyle holds the style overrides of a Series.
+// Nil fields are deduced from the current Style and Colormap.
+type SeriesStyle struct {
+	LineColor  *imgui.Vec4 `json:"lineColor,omitempty"`
+	LineWeight *float32    `json:"lineWeight,omitempty"`
+	FillColor  *imgui.Vec4 `json:"fillColor,omitempty"`
+	FillAlpha  *float32    `json:"fillAlpha,omitempty"`
+	Marker     *Marker     `json:"marker,omitempty"`
+	MarkerSize *float32    `json:"markerSize,omitempty"`
+}
+
+// ParseFigure decodes a Figure from JSON and validates it.
+func ParseFigure(data []byte) (*Figure, error) {
+	f := &Figure{}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, err
+	}
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Validate checks the Figure for values Render cannot plot.
+func (f *Figure) Validate() error {
+	for i, a := range f.Axes {
+		if a.Axis < 0 || a.Axis >= Axis_Count {
+			return fmt.Errorf("implot: Figure %q: axis #%d: invalid axis %d", f.Title, i, a.Axis)
+		}
+	}
+	for i, s := range f.Series {
+		switch s.Kind {
+		case SeriesKind_Line, SeriesKind_Scatter, SeriesKind_Stairs, SeriesKind_ShadedRef, SeriesKind_Bars, SeriesKind_BarsH:
+		default:
+			return fmt.Errorf("implot: Figure %q: series #%d (%q): unknown kind %q", f.Title, i, s.Label, s.Kind)
+		}
+		if len(s.X) != 0 && len(s.X) != len(s.Y) {
+			return fmt.Errorf("implot: Figure %q: series #%d (%q): len(x)=%d, len(y)=%d", f.Title, i, s.Label, len(s.X), len(s.Y))
+		}
+	}
+	return nil
+}
+
+// Render draws the Figure in the current ImGui window.
+//
+// It returns false if the plot is not visible, like BeginPlot.
+// Render panics if the Figure is invalid; call Validate first for an error instead.
+func (f *Figure) Render() bool {
+	if err := f.Validate(); err != nil {
+		panic(err)
+	}
+	if !BeginPlotV(f.Title, f.Size, f.Flags) {
+		return false
+	}
+	defer EndPlot()
+
+	for _, a := range f.Axes {
+		SetupAxis(a.Axis, a.Label, a.Flags)
+		if a.Limits != nil {
+			cond := a.LimitsCond
+			if cond == 0 {
+				cond = Condition_Once
+			}
+			SetupAxisLimits(a.Axis, a.Limits.Min, a.Limits.Max, cond)
+		}
+		if len(a.Format) != 0 {
+			SetupAxisFormat(a.Axis, a.Format)
+		}
+	}
+	if f.Legend != nil {
+		SetupLegend(f.Legend.Location, f.Legend.Flags)
+	}
+
+	for i := range f.Series {
+		f.Series[i].render()
+	}
+	return true
+}
+
+// render issues the SetNextXXXStyle and Plot* calls of a Series.
+func (s *Series) render() {
+	if st := s.Style; st != nil {
+		if st.LineColor != nil || st.LineWeight != nil {
+			SetNextLineStyle(vec4Or(st.LineColor, AutoColor), float32Or(st.LineWeight, Auto))
+		}
+		if st.FillColor != nil || st.FillAlpha != nil {
+			SetNextFillStyle(vec4Or(st.FillColor, AutoColor), float32Or(st.FillAlpha, Auto))
+		}
+		if st.Marker != nil || st.MarkerSize != nil {
+			marker := Marker(Marker_None)
+			if st.Marker != nil {
+				marker = *st.Marker
+			}
+			SetNextMarkerStyle(marker, float32Or(st.MarkerSize, Auto), AutoColor, Auto, AutoColor)
+		}
+	}
+
+	xscale := s.XScale
+	if xscale == 0 {
+		xscale = 1
+	}
+	barWidth := s.BarWidth
+	if barWidth == 0 {
+		barWidth = 0.67
+	}
+
+	if len(s.X) == 0 {
+		switch s.Kind {
+		case SeriesKind_Line:
+			PlotLineV(s.Label, s.Y, xscale, s.X0)
+		case SeriesKind_Scatter:
+			PlotScatterV(s.Label, s.Y, xscale, s.X0)
+		case SeriesKind_Stairs:
+			PlotStairsV(s.Label, s.Y, xscale, s.X0)
+		case SeriesKind_ShadedRef:
+			PlotShadedRefV(s.Label, s.Y, s.YRef, xscale, s.X0)
+		case SeriesKind_Bars:
+			PlotBarsV(s.Label, s.Y, barWidth, s.X0)
+		case SeriesKind_BarsH:
+			PlotBarsHV(s.Label, s.Y, barWidth, s.X0)
+		}
+	} else {
+		switch s.Kind {
+		case SeriesKind_Line:
+			PlotLineXY(s.Label, s.X, s.Y)
+		case SeriesKind_Scatter:
+			PlotScatterXY(s.Label, s.X, s.Y)
+		case SeriesKind_Stairs:
+			PlotStairsXY(s.Label, s.X, s.Y)
+		case SeriesKind_ShadedRef:
+			PlotShadedRefXY(s.Label, s.X, s.Y, s.YRef)
+		case SeriesKind_Bars:
+			PlotBarsXY(s.Label, s.X, s.Y, barWidth)
+		case SeriesKind_BarsH:
+			PlotBarsHXY(s.Label, s.X, s.Y, barWidth)
+		}
+	}
+}
+
+func vec4Or(v *imgui.Vec4, def imgui.Vec4) imgui.Vec4 {
+	if v == nil {
+		return def
+	}
+	return *v
+}
+
+func float32Or(v *float32, def float32) float32 {
+	if v == nil {
+		return def
+	}
+	return *v
+}
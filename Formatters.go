@@ -0,0 +1,144 @@
+package implot
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The XXXFormatter functions return ready-made Formatters, to be passed to
+// SetupAxisFormatCallback (userData is not used and can be nil):
+//
+//	implot.SetupAxisFormatCallback(implot.Axis_Y1, implot.SIFormatter("V", 1), nil)
+//
+// ImPlot formats the mouse position text with the same formatter as the
+// axis, so the readout matches the tick labels.
+
+// siPrefixes are the SI prefixes from 1e-24 to 1e24, 1e0 at index 8.
+var siPrefixes = [...]string{"y", "z", "a", "f", "p", "n", "µ", "m", "", "k", "M", "G", "T", "P", "E", "Z", "Y"}
+
+// engineering splits v into a mantissa in [1, 1000) and an exponent of
+// a multiple of 3, with the mantissa rounded to #precision decimals.
+func engineering(v float64, precision int) (mant float64, exp int) {
+	if v == 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+		return v, 0
+	}
+	exp = int(math.Floor(math.Log10(math.Abs(v))/3)) * 3
+	mant = v / math.Pow(10, float64(exp))
+
+	// Rounding might carry the mantissa to 1000, e.g. 999.96 -> 1000.0
+	p := math.Pow(10, float64(precision))
+	if math.Abs(math.Round(mant*p)/p) >= 1000 {
+		mant /= 1000
+		exp += 3
+	}
+	return
+}
+
+// SIFormatter formats values with an SI prefix and a unit, with #precision
+// decimals, e.g. "1.2 kV" or "3.4 µA". The unit can be empty, e.g. "1.2 k" or "3.4".
+//
+// Values out of [1e-24, 1e27) are shown in the E notation.
+func SIFormatter(unit string, precision int) Formatter {
+	return func(val float64, _ interface{}) string {
+		mant, exp := engineering(val, precision)
+		i := exp/3 + 8
+		if i < 0 || i >= len(siPrefixes) {
+			return withSuffix(strconv.FormatFloat(val, 'e', precision, 64), unit)
+		}
+		return withSuffix(strconv.FormatFloat(mant, 'f', precision, 64), siPrefixes[i]+unit)
+	}
+}
+
+// withSuffix returns #s followed by a space and #suffix, or #s alone if #suffix is empty.
+func withSuffix(s, suffix string) string {
+	if suffix == "" {
+		return s
+	}
+	return s + " " + suffix
+}
+
+// EngineeringFormatter formats values in the engineering notation, with the
+// exponent a multiple of 3 and #precision decimals, e.g. "12.3e3" or "4.56e-6".
+// Values in [1, 1000) are shown without an exponent.
+func EngineeringFormatter(precision int) Formatter {
+	return func(val float64, _ interface{}) string {
+		mant, exp := engineering(val, precision)
+		s := strconv.FormatFloat(mant, 'f', precision, 64)
+		if exp != 0 {
+			s += "e" + strconv.Itoa(exp)
+		}
+		return s
+	}
+}
+
+var binaryPrefixes = [...]string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// BytesFormatter formats values as a number of bytes with binary prefixes and
+// #precision decimals, e.g. "512 B", "1.5 KiB" or "3.2 GiB".
+func BytesFormatter(precision int) Formatter {
+	return func(val float64, _ interface{}) string {
+		abs, i := math.Abs(val), 0
+		for abs >= 1024 && i < len(binaryPrefixes)-1 {
+			abs /= 1024
+			i++
+		}
+		if i == 0 {
+			return strconv.FormatFloat(val, 'f', -1, 64) + " B"
+		}
+		return strconv.FormatFloat(math.Copysign(abs, val), 'f', precision, 64) + " " + binaryPrefixes[i]
+	}
+}
+
+// DurationFormatter formats values in units of #unit as a time.Duration,
+// e.g. with unit=time.Second, 90 is shown as "1m30s".
+//
+// The durations are rounded to 1/1000 of the unit, to hide floating-point errors.
+// Durations out of the range of time.Duration (about 292 years) are shown in
+// hours, e.g. "8.77e+06h" for 1000 years.
+func DurationFormatter(unit time.Duration) Formatter {
+	round := unit / 1000
+	return func(val float64, _ interface{}) string {
+		ns := val * float64(unit)
+		if !(math.Abs(ns) < math.MaxInt64) {
+			return strconv.FormatFloat(ns/float64(time.Hour), 'g', 3, 64) + "h"
+		}
+		return time.Duration(ns).Round(round).String()
+	}
+}
+
+// PercentFormatter formats fractions as percentages with #precision decimals,
+// e.g. 0.25 is shown as "25%".
+func PercentFormatter(precision int) Formatter {
+	return func(val float64, _ interface{}) string {
+		return strconv.FormatFloat(val*100, 'f', precision, 64) + "%"
+	}
+}
+
+// CurrencyFormatter formats values as an amount of money with a leading
+// #symbol, thousands separators and #precision decimals,
+// e.g. "$1,234.50" or "-€12.00".
+func CurrencyFormatter(symbol string, precision int) Formatter {
+	return func(val float64, _ interface{}) string {
+		s := strconv.FormatFloat(math.Abs(val), 'f', precision, 64)
+		intPart, frac := s, ""
+		if dot := strings.IndexByte(s, '.'); dot != -1 {
+			intPart, frac = s[:dot], s[dot:]
+		}
+
+		var b strings.Builder
+		if val < 0 && strings.Trim(s, "0.") != "" {
+			b.WriteByte('-')
+		}
+		b.WriteString(symbol)
+		for i, c := range intPart {
+			if i != 0 && (len(intPart)-i)%3 == 0 {
+				b.WriteByte(',')
+			}
+			b.WriteRune(c)
+		}
+		b.WriteString(frac)
+		return b.String()
+	}
+}
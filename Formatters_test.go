@@ -0,0 +1,67 @@
+package implot
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEngineering(t *testing.T) {
+	tests := []struct {
+		v         float64
+		precision int
+		mant      float64
+		exp       int
+	}{
+		{0, 1, 0, 0},
+		{1, 1, 1, 0},
+		{999, 1, 999, 0},
+		{1234, 1, 1.234, 3},
+		{0.002, 1, 2, -3},
+		{-45600, 2, -45.6, 3},
+		{999.96, 1, 0.99996, 3},   // rounds to 1000.0, carried to 1.0e3
+		{999.94, 1, 999.94, 0},    // rounds to 999.9
+		{-999960, 1, -0.99996, 6}, // the carry keeps the sign
+		{0.00099996, 1, 0.99996, -3},
+	}
+	for _, tt := range tests {
+		mant, exp := engineering(tt.v, tt.precision)
+		if exp != tt.exp || math.Abs(mant-tt.mant) > 1e-9 {
+			t.Errorf("engineering(%g, %d) = %g, %d, want %g, %d", tt.v, tt.precision, mant, exp, tt.mant, tt.exp)
+		}
+	}
+}
+
+func TestFormatters(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Formatter
+		v    float64
+		want string
+	}{
+		{"SI", SIFormatter("V", 1), 1200, "1.2 kV"},
+		{"SI carry", SIFormatter("", 1), 999.96, "1.0 k"},
+		{"SI no prefix", SIFormatter("V", 1), 3.4, "3.4 V"},
+		{"SI no prefix no unit", SIFormatter("", 1), 3.4, "3.4"},
+		{"SI out of range", SIFormatter("V", 1), 1e30, "1.0e+30 V"},
+		{"engineering", EngineeringFormatter(2), 12345, "12.35e3"},
+		{"engineering no exponent", EngineeringFormatter(1), 42, "42.0"},
+		{"bytes", BytesFormatter(1), 1536, "1.5 KiB"},
+		{"bytes small", BytesFormatter(1), 512, "512 B"},
+		{"duration", DurationFormatter(time.Second), 90, "1m30s"},
+		{"duration overflow", DurationFormatter(time.Second), 1e10, "2.78e+06h"},
+		{"duration negative overflow", DurationFormatter(time.Second), -1e10, "-2.78e+06h"},
+		{"percent", PercentFormatter(0), 0.25, "25%"},
+		{"currency", CurrencyFormatter("$", 2), 1234.5, "$1,234.50"},
+		{"currency negative", CurrencyFormatter("€", 2), -12, "-€12.00"},
+		{"currency millions", CurrencyFormatter("$", 0), 1234567, "$1,234,567"},
+		{"currency hundreds", CurrencyFormatter("$", 0), 999, "$999"},
+		{"currency negative zero", CurrencyFormatter("€", 2), -0.001, "€0.00"},
+		{"currency no decimals", CurrencyFormatter("$", 0), -1000.4, "-$1,000"},
+	}
+	for _, tt := range tests {
+		if got := tt.f(tt.v, nil); got != tt.want {
+			t.Errorf("%s: %g formatted as %q, want %q", tt.name, tt.v, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package implot
+
+// #include "wrapper/Plot.h"
+import "C"
+import (
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// LODSeries is a fixed series of sorted X/Y coords with a level-of-detail
+// pyramid, for datasets too large to be downsampled every frame
+// (see PlotLineMinMax).
+//
+// Level k of the pyramid has the minimum, maximum and mean of every 4^k
+// samples. PlotLineLOD and PlotLineLODMean pick the coarsest level with
+// at least one bucket per pixel of the visible X range, and plot only its
+// visible window, without copying. The pyramid takes about 2 times the
+// memory of the Y values.
+//
+// The data is not copied, and must not be modified after building.
+// With the scales set by SetupAxisScale, the visible window is copied
+// and transformed every frame.
+type LODSeries struct {
+	xs, ys []float64
+	levels []lodLevel
+	built  int32 // number of levels built, accessed atomically
+	done   sync.WaitGroup
+}
+
+// lodLevel is a level of the pyramid.
+type lodLevel struct {
+	shift    uint    // log2 of the bucket size
+	envelope []Point // (x, min), (x, max) of every bucket, x the first X in it
+	means    []Point // (x, mean) of every bucket
+}
+
+// lodFactorShift is log2 of the ratio of bucket sizes between levels.
+const lodFactorShift = 2
+
+// lodMinBuckets is the number of buckets under which no more levels are built.
+const lodMinBuckets = 1024
+
+// NewLODSeries builds the pyramid of a series of sorted X/Y coords.
+// It panics if len(xs) != len(ys).
+func NewLODSeries(xs, ys []float64) *LODSeries {
+	s := newLODSeries(xs, ys)
+	s.build()
+	return s
+}
+
+// NewLODSeriesAsync returns a LODSeries and builds its pyramid in background
+// goroutines. It can be plotted meanwhile, with the levels built so far.
+// It panics if len(xs) != len(ys).
+func NewLODSeriesAsync(xs, ys []float64) *LODSeries {
+	s := newLODSeries(xs, ys)
+	go s.build()
+	return s
+}
+
+func newLODSeries(xs, ys []float64) *LODSeries {
+	if len(xs) != len(ys) {
+		panic("NewLODSeries called with slices of different lengths")
+	}
+	s := &LODSeries{xs: xs, ys: ys}
+	for shift := uint(lodFactorShift); len(xs)>>shift >= lodMinBuckets; shift += lodFactorShift {
+		s.levels = append(s.levels, lodLevel{shift: shift})
+	}
+	s.done.Add(1)
+	return s
+}
+
+// build builds every level, publishing them one by one.
+func (s *LODSeries) build() {
+	defer s.done.Done()
+	for k := range s.levels {
+		if k == 0 {
+			s.buildFirst(&s.levels[0])
+		} else {
+			s.buildNext(&s.levels[k], &s.levels[k-1])
+		}
+		atomic.StoreInt32(&s.built, int32(k+1))
+	}
+}
+
+// buildFirst builds the first level from the data, in parallel.
+func (s *LODSeries) buildFirst(l *lodLevel) {
+	size := 1 << l.shift
+	m := (len(s.xs) + size - 1) / size
+	l.envelope = make([]Point, 2*m)
+	l.means = make([]Point, m)
+
+	workers := runtime.GOMAXPROCS(0)
+	chunk := (m + workers - 1) / workers
+	var wg sync.WaitGroup
+	for b0 := 0; b0 < m; b0 += chunk {
+		b1 := minint(b0+chunk, m)
+		wg.Add(1)
+		go func(b0, b1 int) {
+			defer wg.Done()
+			for b := b0; b < b1; b++ {
+				i0, i1 := b*size, minint((b+1)*size, len(s.xs))
+				lo, hi, sum := s.ys[i0], s.ys[i0], 0.0
+				for _, y := range s.ys[i0:i1] {
+					if y < lo {
+						lo = y
+					}
+					if y > hi {
+						hi = y
+					}
+					sum += y
+				}
+				x := s.xs[i0]
+				l.envelope[2*b], l.envelope[2*b+1] = Point{X: x, Y: lo}, Point{X: x, Y: hi}
+				l.means[b] = Point{X: x, Y: sum / float64(i1-i0)}
+			}
+		}(b0, b1)
+	}
+	wg.Wait()
+}
+
+// buildNext builds a level from the previous one.
+func (s *LODSeries) buildNext(l, prev *lodLevel) {
+	size := 1 << l.shift
+	m := (len(s.xs) + size - 1) / size
+	l.envelope = make([]Point, 2*m)
+	l.means = make([]Point, m)
+
+	const factor = 1 << lodFactorShift
+	prevSize := float64(int(1) << prev.shift)
+	for b := 0; b < m; b++ {
+		p0, p1 := b*factor, minint((b+1)*factor, len(prev.means))
+		lo, hi, sum, n := prev.envelope[2*p0].Y, prev.envelope[2*p0+1].Y, 0.0, 0.0
+		for p := p0; p < p1; p++ {
+			if v := prev.envelope[2*p].Y; v < lo {
+				lo = v
+			}
+			if v := prev.envelope[2*p+1].Y; v > hi {
+				hi = v
+			}
+			// The last bucket of the previous level might be partial
+			w := prevSize
+			if p == len(prev.means)-1 {
+				w = float64(len(s.xs) - p*int(prevSize))
+			}
+			sum += prev.means[p].Y * w
+			n += w
+		}
+		x := prev.means[p0].X
+		l.envelope[2*b], l.envelope[2*b+1] = Point{X: x, Y: lo}, Point{X: x, Y: hi}
+		l.means[b] = Point{X: x, Y: sum / n}
+	}
+}
+
+// Ready returns if the pyramid is built.
+func (s *LODSeries) Ready() bool {
+	return int(atomic.LoadInt32(&s.built)) == len(s.levels)
+}
+
+// Wait blocks until the pyramid is built.
+func (s *LODSeries) Wait() {
+	s.done.Wait()
+}
+
+// Len returns the number of samples.
+func (s *LODSeries) Len() int { return len(s.xs) }
+
+// window returns the level to plot (-1 for the data itself) and its visible
+// buckets [b0, b1) for the current plot.
+//
+// While the pyramid is being built, the visible data might be too large to be
+// plotted as is. Then it is downsampled into the scratch buffer, to the
+// minimum and maximum like PlotLineMinMax or to the #mean of every pixel
+// column, and returned as level -2.
+func (s *LODSeries) window(mean bool) (level, b0, b1 int) {
+	xmin, xmax, pixels, fitting := lineView()
+	lo, hi := 0, len(s.xs)
+	if !fitting {
+		lo, hi = visibleRange(s.xs, xmin, xmax)
+	}
+
+	level = -1
+	built := int(atomic.LoadInt32(&s.built))
+	for k := 0; k < built && (hi-lo)>>s.levels[k].shift >= int(pixels); k++ {
+		level = k
+	}
+	if level == -1 {
+		if hi-lo > 4*int(pixels) {
+			if fitting && len(s.xs) > 0 {
+				xmin, xmax = s.xs[0], s.xs[len(s.xs)-1]
+			}
+			st := current()
+			if mean {
+				st.scratch = bucketMeans(st.scratch[:0], s.xs[lo:hi], s.ys[lo:hi], xmin, xmax, int(pixels))
+			} else {
+				st.scratch = minMax(st.scratch[:0], s.xs[lo:hi], s.ys[lo:hi], xmin, xmax, int(pixels))
+			}
+			return -2, 0, len(st.scratch)
+		}
+		return -1, lo, hi
+	}
+	shift := s.levels[level].shift
+	return level, lo >> shift, (hi-1)>>shift + 1
+}
+
+// bucketMeans appends to #pts the mean of #ys in each of #buckets buckets
+// of X in [xmin, xmax], at the first X in it.
+func bucketMeans(pts []Point, xs, ys []float64, xmin, xmax float64, buckets int) []Point {
+	if len(xs) <= buckets || !(xmax > xmin) {
+		for i := range xs {
+			pts = append(pts, Point{X: xs[i], Y: ys[i]})
+		}
+		return pts
+	}
+
+	scale := float64(buckets) / (xmax - xmin)
+	cur, start, sum := math.Floor((xs[0]-xmin)*scale), 0, 0.0
+	for i := range xs {
+		if b := math.Floor((xs[i] - xmin) * scale); b != cur {
+			pts = append(pts, Point{X: xs[start], Y: sum / float64(i-start)})
+			cur, start, sum = b, i, 0
+		}
+		sum += ys[i]
+	}
+	return append(pts, Point{X: xs[start], Y: sum / float64(len(xs)-start)})
+}
+
+// plotLOD plots the window of a LODSeries, with the scales of the plot applied.
+func (s *LODSeries) plot(label string, mean bool) {
+	level, b0, b1 := s.window(mean)
+	if level == -1 {
+		typ, xp, yp, count, stride := wrapXYSliceScaled(s.xs[b0:b1], s.ys[b0:b1])
+		C.igpPlotLineXY(typ, wrapString(label), xp, yp, count, 0, stride)
+		return
+	}
+
+	var xp, yp unsafe.Pointer
+	var count, stride C.int
+	switch {
+	case level == -2:
+		st := current()
+		st.scratch = scaledPoints(st.scratch)
+		xp, yp, count, stride = wrapPointSlice(st.scratch)
+	case mean:
+		xp, yp, count, _, stride = wrapPointSliceScaled(s.levels[level].means[b0:b1], 0)
+	default:
+		xp, yp, count, _, stride = wrapPointSliceScaled(s.levels[level].envelope[2*b0:2*b1], 0)
+	}
+	C.igpPlotLineXY(C.igpDataType_Double, wrapString(label), xp, yp, count, 0, stride)
+}
+
+// PlotLineLOD plots the envelope of a LODSeries: a line through the minimum
+// and the maximum of every bucket, or the data itself when zoomed in enough.
+// Every peak stays visible.
+func PlotLineLOD(label string, s *LODSeries) {
+	validateLock("PlotLineLOD")
+	s.plot(label, false)
+}
+
+// PlotLineLODMean plots a line through the mean of every bucket of a
+// LODSeries, or the data itself when zoomed in enough.
+func PlotLineLODMean(label string, s *LODSeries) {
+	validateLock("PlotLineLODMean")
+	s.plot(label, true)
+}
@@ -6,3 +6,9 @@
 #include "wrapper/Plot.cpp"
 #include "wrapper/Setup.cpp"
 #include "wrapper/Style.cpp"
+#include "wrapper/Time.cpp"
+#include "wrapper/Colormap.cpp"
+#include "wrapper/Batch.cpp"
+#include "wrapper/Downsample.cpp"
+#include "wrapper/Candlestick.cpp"
+#include "wrapper/CustomItem.cpp"
@@ -0,0 +1,333 @@
+package implot
+
+// #include "wrapper/Setup.h"
+import "C"
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// Locator computes the ticks of an axis from its visible range, like the
+// locators of matplotlib. Set one up with SetupAxisLocator, and the ticks
+// adapt every frame as the user pans and zooms.
+type Locator interface {
+	// Ticks appends the tick values of an axis showing #view over #pixels
+	// pixels to #values, and their labels to #labels.
+	//
+	// Labels can be left out (returning #labels as is) to format the values
+	// with the format of the axis. Otherwise there must be one for every value.
+	//
+	// #pixels is 0 in the first frame of a plot, when its size is not known yet.
+	Ticks(view Range, pixels float32, values []float64, labels []string) ([]float64, []string)
+}
+
+// LocatorFunc adapts a function to a Locator.
+type LocatorFunc func(view Range, pixels float32, values []float64, labels []string) ([]float64, []string)
+
+// Ticks calls f.
+func (f LocatorFunc) Ticks(view Range, pixels float32, values []float64, labels []string) ([]float64, []string) {
+	return f(view, pixels, values, labels)
+}
+
+// SetupAxisLocator sets an axis' ticks to those computed by a Locator
+// for the current visible range of the axis. To keep the default ticks,
+// set keepDefaults=true.
+//
+// The range is the one of the last frame, or the one set by SetupAxisLimits
+// if it is called before this.
+func SetupAxisLocator(axis Axis, locator Locator, keepDefaults bool) {
+	validateSetup("SetupAxisLocator")
+	var vmin, vmax C.double
+	var pixels C.float
+	C.igpGetSetupAxisView(C.igpAxis(axis), &vmin, &vmax, &pixels)
+
+	s := current()
+	s.tickValues, s.tickLabels = locator.Ticks(Range{Min: float64(vmin), Max: float64(vmax)}, float32(pixels), s.tickValues[:0], s.tickLabels[:0])
+	labels := s.tickLabels
+	if len(labels) != len(s.tickValues) {
+		labels = nil
+	}
+	SetupAxisTickValues(axis, s.tickValues, labels, keepDefaults)
+
+	// Do not keep the label strings alive
+	for i := range s.tickLabels {
+		s.tickLabels[i] = ""
+	}
+}
+
+// maxTicks is the most ticks a built-in Locator returns, to keep zooming out
+// from producing millions of them.
+const maxTicks = 1000
+
+// ticksFor returns the number of ticks fitting in #pixels, at least 2.
+func ticksFor(pixels float32, pixelsPerTick float32) int {
+	if pixels <= 0 {
+		return 5
+	}
+	if n := int(pixels / pixelsPerTick); n > 2 {
+		return n
+	}
+	return 2
+}
+
+// MultipleLocator places ticks at every multiple of Base, plus Offset.
+//
+// If that is more than 1000 ticks, only every n-th multiple is used.
+type MultipleLocator struct {
+	Base, Offset float64
+}
+
+// Ticks implements Locator.
+func (l MultipleLocator) Ticks(view Range, pixels float32, values []float64, labels []string) ([]float64, []string) {
+	if !(l.Base > 0) {
+		return values, labels
+	}
+	first := math.Ceil((view.Min - l.Offset) / l.Base)
+	last := math.Floor((view.Max - l.Offset) / l.Base)
+	step := math.Max(1, math.Ceil((last-first+1)/maxTicks))
+	first = math.Ceil(first/step) * step
+	if math.IsInf(first, 0) || math.IsNaN(first) || math.IsInf(last, 0) || math.IsNaN(last) {
+		return values, labels
+	}
+	// Counted with an integer: past 2^53, k += step can round back to k
+	n := len(values)
+	for i := 0; i < maxTicks; i++ {
+		k := first + float64(i)*step
+		if k > last {
+			break
+		}
+		// Far from 0, consecutive multiples can round to the same value
+		if v := l.Offset + k*l.Base; len(values) == n || v != values[len(values)-1] {
+			values = append(values, v)
+		}
+	}
+	return values, labels
+}
+
+// MaxNLocator places at most N ticks at "nice" values: multiples of
+// 1, 2, 2.5 or 5 times a power of 10.
+//
+// If N <= 0, it is decided from the pixel length of the axis, about one tick
+// every 100 pixels. If Integer is set, only integers are used.
+type MaxNLocator struct {
+	N       int
+	Integer bool
+}
+
+// Ticks implements Locator.
+func (l MaxNLocator) Ticks(view Range, pixels float32, values []float64, labels []string) ([]float64, []string) {
+	n := l.N
+	if n <= 0 {
+		n = ticksFor(pixels, 100)
+	}
+	size := view.Size()
+	if !(size > 0) || math.IsInf(size, 0) {
+		return values, labels
+	}
+
+	raw := size / float64(n)
+	mag := math.Pow(10, math.Floor(math.Log10(raw)))
+	step := 10 * mag
+	for _, s := range [...]float64{1, 2, 2.5, 5} {
+		if s*mag >= raw {
+			step = s * mag
+			break
+		}
+	}
+	if l.Integer && step < 1 {
+		step = 1
+	}
+	return MultipleLocator{Base: step}.Ticks(view, pixels, values, labels)
+}
+
+// MonthLocator places ticks at the start of every Step-th month, on an axis
+// of UNIX seconds (e.g. with AxisFlags_Time).
+//
+// If Step <= 0, it is picked from 1, 2, 3, 4, 6 or multiples of 12 months,
+// to keep the labels about 80 pixels apart. Labels are formatted with Format,
+// by default "Jan 2006". Location is the time zone, by default UTC.
+type MonthLocator struct {
+	Step     int
+	Format   string
+	Location *time.Location
+}
+
+// Ticks implements Locator.
+func (l MonthLocator) Ticks(view Range, pixels float32, values []float64, labels []string) ([]float64, []string) {
+	loc, format := l.Location, l.Format
+	if loc == nil {
+		loc = time.UTC
+	}
+	if format == "" {
+		format = "Jan 2006"
+	}
+	if !(view.Size() > 0) || math.Abs(view.Min) > 1e15 || math.Abs(view.Max) > 1e15 {
+		return values, labels
+	}
+
+	tmin, tmax := unixTime(view.Min, loc), unixTime(view.Max, loc)
+	months := (tmax.Year()-tmin.Year())*12 + int(tmax.Month()-tmin.Month()) + 1
+
+	step := l.Step
+	if step <= 0 {
+		n := ticksFor(pixels, 80)
+		step = 1
+		for _, s := range [...]int{1, 2, 3, 4, 6, 12} {
+			step = s
+			if months/s <= n {
+				break
+			}
+		}
+		if months/step > n {
+			// The smallest multiple of 12 with months/step <= n
+			step = (months/(n+1)/12 + 1) * 12
+		}
+	}
+	for months/step > maxTicks {
+		step *= 2
+	}
+
+	// Align the months to multiples of step since year 0, so they do not jump when panning
+	m := tmin.Year()*12 + int(tmin.Month()) - 1
+	m -= ((m % step) + step) % step
+	for {
+		t := time.Date(m/12, time.Month(m%12+1), 1, 0, 0, 0, 0, loc)
+		if t.After(tmax) {
+			break
+		}
+		if !t.Before(tmin) {
+			values = append(values, timeUnix(t))
+			labels = append(labels, t.Format(format))
+		}
+		m += step
+	}
+	return values, labels
+}
+
+// WeekLocator places ticks at the start of Weekday, every Step weeks, on an
+// axis of UNIX seconds (e.g. with AxisFlags_Time).
+//
+// If Step <= 0, it is picked from 1, 2 or 4 weeks, or multiples of 13 weeks,
+// to keep the labels about 80 pixels apart. Labels are formatted with Format,
+// by default "Jan 2". Location is the time zone, by default UTC.
+type WeekLocator struct {
+	Weekday  time.Weekday
+	Step     int
+	Format   string
+	Location *time.Location
+}
+
+// Ticks implements Locator.
+func (l WeekLocator) Ticks(view Range, pixels float32, values []float64, labels []string) ([]float64, []string) {
+	loc, format := l.Location, l.Format
+	if loc == nil {
+		loc = time.UTC
+	}
+	if format == "" {
+		format = "Jan 2"
+	}
+	if !(view.Size() > 0) || math.Abs(view.Min) > 1e15 || math.Abs(view.Max) > 1e15 {
+		return values, labels
+	}
+
+	// In float seconds, as a time.Duration saturates at 292 years
+	const week = 7 * 24 * 3600
+	tmin, tmax := unixTime(view.Min, loc), unixTime(view.Max, loc)
+	weeks := int(view.Size()/week) + 1
+
+	step := l.Step
+	if step <= 0 {
+		n := ticksFor(pixels, 80)
+		step = 1
+		for _, s := range [...]int{1, 2, 4, 13} {
+			step = s
+			if weeks/s <= n {
+				break
+			}
+		}
+		if weeks/step > n {
+			// The smallest multiple of 13 with weeks/step <= n
+			step = (weeks/(n+1)/13 + 1) * 13
+		}
+	}
+	for weeks/step > maxTicks {
+		step *= 2
+	}
+
+	// Count the weeks from the first Weekday of 1970, so they do not jump when panning
+	ref := time.Date(1970, 1, 1, 0, 0, 0, 0, loc)
+	ref = ref.AddDate(0, 0, (int(l.Weekday)-int(ref.Weekday())+7)%7)
+	k := int(math.Floor((view.Min - float64(ref.Unix())) / week))
+	k -= ((k % step) + step) % step
+	for i := 0; i <= maxTicks; i, k = i+1, k+step {
+		t := ref.AddDate(0, 0, 7*k)
+		if t.After(tmax) {
+			break
+		}
+		if !t.Before(tmin) {
+			values = append(values, timeUnix(t))
+			labels = append(labels, t.Format(format))
+		}
+	}
+	return values, labels
+}
+
+// LogMinorLocator places ticks at every integer multiple of the powers of Base
+// (by default 10), for axes with AxisFlags_LogScale: 1, 2, ... 9, 10, 20, ...
+//
+// Only the powers of Base are labeled. If there would be more than 1000 ticks,
+// only the powers are placed.
+type LogMinorLocator struct {
+	Base int
+}
+
+// Ticks implements Locator.
+func (l LogMinorLocator) Ticks(view Range, pixels float32, values []float64, labels []string) ([]float64, []string) {
+	base := l.Base
+	if base < 2 {
+		base = 10
+	}
+	if !(view.Min > 0) || !(view.Max > view.Min) || math.IsInf(view.Max, 0) {
+		return values, labels
+	}
+
+	b := float64(base)
+	emin := int(math.Floor(math.Log(view.Min) / math.Log(b)))
+	emax := int(math.Ceil(math.Log(view.Max) / math.Log(b)))
+	minors := (emax-emin+1)*(base-1) <= maxTicks
+	if !minors && emax-emin+1 > maxTicks {
+		return values, labels
+	}
+
+	for e := emin; e <= emax; e++ {
+		p := math.Pow(b, float64(e))
+		for k := 1; k < base; k++ {
+			if k > 1 && !minors {
+				break
+			}
+			v := float64(k) * p
+			if v < view.Min || v > view.Max {
+				continue
+			}
+			values = append(values, v)
+			if k == 1 {
+				labels = append(labels, strconv.FormatFloat(v, 'g', -1, 64))
+			} else {
+				labels = append(labels, "")
+			}
+		}
+	}
+	return values, labels
+}
+
+// unixTime converts UNIX seconds to a time.Time.
+func unixTime(sec float64, loc *time.Location) time.Time {
+	s := math.Floor(sec)
+	return time.Unix(int64(s), int64((sec-s)*1e9)).In(loc)
+}
+
+// timeUnix converts a time.Time to UNIX seconds.
+func timeUnix(t time.Time) float64 {
+	return float64(t.Unix()) + float64(t.Nanosecond())/1e9
+}
@@ -2,6 +2,7 @@ package implot
 
 // #include "wrapper/Main.h"
 import "C"
+import "fmt"
 
 // Version returns a version string, e.g., "0.13 WIP".
 func Version() string {
@@ -23,3 +24,29 @@ func ShowDemoWindow(open *bool) {
 func igpPanic(msg *C.char) {
 	panic(C.GoString(msg))
 }
+
+// AssertionError is the value ImPlot panics with when an assertion fails,
+// typically from an API misuse like Setup calls after SetupFinish,
+// or EndPlot without BeginPlot.
+//
+// It can be recovered, but note that the state of ImPlot might be left
+// inconsistent (e.g. a plot begun but never ended) for the rest of the frame.
+type AssertionError struct {
+	Expression string
+	File       string
+	Line       int
+}
+
+// Error returns the string representation.
+func (err AssertionError) Error() string {
+	return fmt.Sprintf("ImPlot assertion failed: %s (%s:%d)", err.Expression, err.File, err.Line)
+}
+
+//export igpPanicAssert
+func igpPanicAssert(expr, file *C.char, line C.int) {
+	panic(AssertionError{
+		Expression: C.GoString(expr),
+		File:       C.GoString(file),
+		Line:       int(line),
+	})
+}
@@ -0,0 +1,176 @@
+package implot
+
+// #include "wrapper/Types.h"
+import "C"
+import (
+	"unsafe"
+
+	"github.com/inkyblackness/imgui-go/v4"
+)
+
+// Option is an optional parameter of BeginPlot, BeginSubplots and the
+// minimal PlotXXX functions. For example:
+//
+//	if implot.BeginPlot("Plot", implot.Size(imgui.Vec2{X: -1, Y: 300}), implot.WithFlags(implot.Flags_NoLegend)) {
+//		implot.PlotBars("Bars", data, implot.BarWidth(0.5), implot.X0(3))
+//		implot.EndPlot()
+//	}
+//
+// Options not applicable to a function are ignored by it. The XXXV
+// variants with positional parameters are still there, and are equivalent.
+type Option func(o *options)
+
+// options is the set of all the optional parameters, with their defaults.
+type options struct {
+	size         imgui.Vec2
+	flags        Flags
+	subplotFlags SubplotFlags
+	rowRatios    []float32
+	colRatios    []float32
+
+	xscale, x0 float64
+	yref       float64
+	barWidth   float64
+	offset     int
+	stride     int
+
+	skipNonFinite  bool
+	pointsPerPixel float64
+	showEquation   bool
+
+	bullColor, bearColor *imgui.Vec4
+	volumes              []float64
+	volumeHeight         float32
+	noTooltip            bool
+
+	horizontal     bool
+	whiskersMinMax bool
+	notched        bool
+	bandwidth      float64
+}
+
+var defaultOptions = options{
+	size:     imgui.Vec2{X: -1, Y: 0},
+	xscale:   1,
+	barWidth: 0.67,
+	stride:   1,
+
+	pointsPerPixel: 2,
+}
+
+// applyOptions returns the defaults with opts applied.
+func applyOptions(opts []Option) options {
+	if len(opts) == 0 {
+		return defaultOptions
+	}
+	o := defaultOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Size sets the frame size of a plot or the entire grid of subplots (default={-1,0}).
+func Size(size imgui.Vec2) Option {
+	return func(o *options) { o.size = size }
+}
+
+// WithFlags sets the Flags of BeginPlot.
+func WithFlags(flags Flags) Option {
+	return func(o *options) { o.flags = flags }
+}
+
+// WithSubplotFlags sets the SubplotFlags of BeginSubplots.
+func WithSubplotFlags(flags SubplotFlags) Option {
+	return func(o *options) { o.subplotFlags = flags }
+}
+
+// RowRatios sets the row ratios of BeginSubplots. See BeginSubplotsV.
+func RowRatios(ratios []float32) Option {
+	return func(o *options) { o.rowRatios = ratios }
+}
+
+// ColRatios sets the column ratios of BeginSubplots. See BeginSubplotsV.
+func ColRatios(ratios []float32) Option {
+	return func(o *options) { o.colRatios = ratios }
+}
+
+// XScale sets the X distance between consecutive values (default=1).
+func XScale(xscale float64) Option {
+	return func(o *options) { o.xscale = xscale }
+}
+
+// X0 sets the X coordinate of the first value (default=0).
+//
+// For PlotBarsH, it is the Y coordinate of the first bar instead.
+func X0(x0 float64) Option {
+	return func(o *options) { o.x0 = x0 }
+}
+
+// YRef sets the horizontal reference of PlotShadedRef (default=0).
+// Set it to +/-INFINITY for infinite fill extents.
+func YRef(yref float64) Option {
+	return func(o *options) { o.yref = yref }
+}
+
+// BarWidth sets the fraction of the available width a bar of PlotBars takes
+// up, or the height of a bar of PlotBarsH. It should be in (0, 1] (default=0.67).
+func BarWidth(width float64) Option {
+	return func(o *options) { o.barWidth = width }
+}
+
+// Offset sets the index of the first value to plot; the values before it are
+// plotted last, wrapping around (default=0). See also RingBuffer.
+func Offset(offset int) Option {
+	return func(o *options) { o.offset = offset }
+}
+
+// Stride makes only every #n-th value to be plotted (default=1).
+func Stride(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.stride = n
+		}
+	}
+}
+
+// SkipNonFinite makes NaN and +/-Inf values to be skipped, instead of drawn
+// as gaps or garbage. The remaining points are joined together.
+//
+// It applies to PlotLine, PlotScatter, PlotStairs, PlotShadedRef and the
+// PlotXXXXYE variants except PlotShadedLinesXYE, and has no effect on integer data. The filtered data
+// is copied into a buffer reused by every plot, and plotted as float64.
+//
+// With a scale set by SetupAxisScale, the non-finite values are always skipped.
+func SkipNonFinite() Option {
+	return func(o *options) { o.skipNonFinite = true }
+}
+
+// PointsPerPixel sets how many points per pixel PlotLineLTTB keeps (default=2).
+func PointsPerPixel(n float64) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.pointsPerPixel = n
+		}
+	}
+}
+
+// strided applies the offset and stride options to the count and byte stride of a slice.
+// The offset is wrapped into [0, count), which ImPlot requires.
+func (o *options) strided(count, stride C.int) (C.int, C.int, C.int) {
+	if o.stride > 1 {
+		n := C.int(o.stride)
+		count, stride = (count+n-1)/n, stride*n
+	}
+	if count == 0 {
+		return 0, 0, stride
+	}
+	return count, (C.int(o.offset)%count + count) % count, stride
+}
+
+// wrapNumberSliceOpts is wrapNumberSlice with the offset and stride options applied.
+func wrapNumberSliceOpts[T Number](slice []T, o *options) (typ C.igpDataType, ptr unsafe.Pointer, count, offset, stride C.int) {
+	typ, ptr, count, stride = wrapNumberSlice(slice)
+	count, offset, stride = o.strided(count, stride)
+	return
+}
@@ -4,9 +4,11 @@ package implot
 // #include "wrapper/Plot.h"
 import "C"
 import (
+	"fmt"
 	"math"
-	"reflect"
 	"unsafe"
+
+	"github.com/inkyblackness/imgui-go/v4"
 )
 
 //-----------------------------------------------------------------------------
@@ -64,27 +66,30 @@ import (
 // Note only for the Go binding:
 //
 // For each type of ImPlot::PlotXXX/PlotXXXG, five functions are presented:
-// PlotXXX & PlotXXXV plots a slice of any number (integer or float),
+// PlotXXX & PlotXXXV plots a slice of any Number type (integer or float),
 // PlotXXXXY plots separate X/Y slices, PlotXXXP plots a slice of points,
 // and PlotXXXG plots a set of points from a given getter.
-// They construct the data on the fly with no callbacks.
+// The getter is called by ImPlot itself, with no intermediate slice.
 //
 // Since slices are so versatile, the Count and Offset parameters are removed.
-// You can just slice the data youself, if you have it.
+// You can just slice the data youself, if you have it. For circular buffers,
+// where the data wraps around, use RingBuffer and PlotXXXRing.
 //
-// All the values parameters in the PlotXXX/PlotXXXV() functions should only be slices or
-// arrays to numeric types, which is then converted to float64. If it is not, it panics.
+// The slices in PlotXXX/PlotXXXV/PlotXXXXY() are passed straight to the
+// ImPlot template instantiation of the element type. They are not copied
+// or converted to float64 on the Go side.
 
 // Callback signature for the data getter.
 //
-// It is called from within PlotXXXG() (the word "within" is subject to change),
-// with idx ranging from 0 to N-1.
+// It is called from within PlotXXXG(), with idx ranging from 0 to N-1,
+// possibly more than once for the same idx. It must not call any ImPlot
+// functions itself.
 type DataGetter func(userData interface{}, idx int) Point
 
 // DataGet generates a slice of Points from a given DataGetter.
 //
-// This puts a lot of stress on the allocator/GC so perhaps we need something else.
-// This still can be useful for the end user however.
+// PlotXXXG() calls the getter directly and does not use this; it can
+// still be useful for the end user however.
 func DataGet(getter DataGetter, userData interface{}, count int) (ps []Point) {
 	ps = make([]Point, count)
 	for i := 0; i < count; i++ {
@@ -93,124 +98,162 @@ func DataGet(getter DataGetter, userData interface{}, count int) (ps []Point) {
 	return
 }
 
-// valueGet converts any slice/array of numeric type to []float64.
-// Again this is bad for allocator/GC so we should need something else.
-func valueGet(values interface{}) (result []float64) {
-	// fast path
-	if f64s, ok := values.([]float64); ok {
-		return f64s
-	}
+// addDataGetter registers a getter in the current context, returning its handle.
+// The handle must be removed with removeDataGetter once the PlotXXXG call returns.
+func addDataGetter(getter DataGetter, userData interface{}) C.uintptr_t {
+	s := current()
+	handle := s.newHandle()
+	s.getters[handle] = struct {
+		get      DataGetter
+		userData interface{}
+	}{get: getter, userData: userData}
+	return C.uintptr_t(handle)
+}
 
-	// good old reflect
-	ref := reflect.ValueOf(values)
-	switch ref.Kind() {
-	case reflect.Array, reflect.Slice:
-		n := ref.Len()
-		if n == 0 {
-			return
-		}
-		result = make([]float64, n)
+// removeDataGetter unregisters a getter added by addDataGetter.
+func removeDataGetter(handle C.uintptr_t) {
+	delete(current().getters, uintptr(handle))
+}
 
-		// construct the values one-by-one
-		for i := 0; i < n; i++ {
-			e := ref.Index(i)
-			switch e.Kind() {
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				result[i] = float64(e.Int())
-			case reflect.Float32, reflect.Float64:
-				result[i] = e.Float()
-			default:
-				panic("PlotXXX called with a slice/array containing non-scalar values")
-			}
-		}
-	default:
-		panic("PlotXXX called with a non-slice, non-array value")
+//export igpgoDataGetterCb
+func igpgoDataGetterCb(handle C.uintptr_t, idx C.int) C.igpPoint {
+	g, ok := current().getters[uintptr(handle)]
+	if !ok {
+		panic(fmt.Errorf("igpgoDataGetterCb() called with invalid getter handle (%d)", handle))
 	}
-	return
+	return g.get(g.userData, int(idx)).wrap()
 }
 
 // PlotLine
 
-// PlotLine plots a standard 2D line plot with minimal parameters.
-// It calls PlotLineV(label, values, 1, 0).
-func PlotLine(label string, values interface{}) {
-	PlotLineV(label, values, 1, 0)
+// PlotLine plots a standard 2D line plot with optional parameters:
+// XScale, X0, Offset, Stride and SkipNonFinite.
+// Without options, it is the same as PlotLineV(label, values, 1, 0).
+func PlotLine[T Number](label string, values []T, opts ...Option) {
+	o := applyOptions(opts)
+	validateLock("PlotLine")
+	if usePoints[T](&o) {
+		xp, yp, count, stride := wrapPointSlice(finitePoints(nil, values, &o))
+		C.igpPlotLineXY(C.igpDataType_Double, wrapString(label), xp, yp, count, 0, stride)
+		return
+	}
+	typ, vp, count, offset, stride := wrapNumberSliceOpts(values, &o)
+	C.igpPlotLine(typ, wrapString(label), vp, count, C.double(o.xscale), C.double(o.x0), offset, stride)
 }
 
 // PlotLineV plots a standard 2D line plot with all parameters.
-func PlotLineV(label string, values interface{}, xscale, x0 float64) {
-	vd := valueGet(values)
-	C.igpPlotLine(wrapString(label), wrapDoubleSlice(vd), C.int(len(vd)), C.double(xscale), C.double(x0))
+func PlotLineV[T Number](label string, values []T, xscale, x0 float64) {
+	validateLock("PlotLineV")
+	if current().scaled {
+		xp, yp, count, stride := wrapScaledPoints(nil, values, valueOptions(xscale, x0), false)
+		C.igpPlotLineXY(C.igpDataType_Double, wrapString(label), xp, yp, count, 0, stride)
+		return
+	}
+	typ, vp, count, stride := wrapNumberSlice(values)
+	C.igpPlotLine(typ, wrapString(label), vp, count, C.double(xscale), C.double(x0), 0, stride)
 }
 
 // PlotLineP plots a standard 2D line plot from a slice of points.
 func PlotLineP(label string, points []Point) {
-	xp, yp, count, stride := wrapPointSlice(points)
-	C.igpPlotLineXY(wrapString(label), xp, yp, count, stride)
+	validateLock("PlotLineP")
+	xp, yp, count, offset, stride := wrapPointSliceScaled(points, 0)
+	C.igpPlotLineXY(C.igpDataType_Double, wrapString(label), xp, yp, count, offset, stride)
 }
 
 // PlotLineXY plots a standard 2D line plot from slices of X/Y coords.
-func PlotLineXY(label string, xs, ys interface{}) {
-	xp, yp, count, stride := wrapXYSlice(valueGet(xs), valueGet(ys))
-	C.igpPlotLineXY(wrapString(label), xp, yp, count, stride)
+func PlotLineXY[T Number](label string, xs, ys []T) {
+	validateLock("PlotLineXY")
+	typ, xp, yp, count, stride := wrapXYSliceScaled(xs, ys)
+	C.igpPlotLineXY(typ, wrapString(label), xp, yp, count, 0, stride)
 }
 
 // PlotLineG plots a standard 2D line plot from a series of points obtained from a callback.
 func PlotLineG(label string, getter DataGetter, userData interface{}, count int) {
-	PlotLineP(label, DataGet(getter, userData, count))
+	validateLock("PlotLineG")
+	handle := addDataGetter(scaledGetter(getter), userData)
+	defer removeDataGetter(handle)
+	C.igpPlotLineG(wrapString(label), handle, C.int(count))
 }
 
 // PlotScatter
 
-// PlotScatter plots a standard 2D scatter plot with minimal parameters.
-// It calls PlotScatterV(label, values, 1, 0).
+// PlotScatter plots a standard 2D scatter plot with optional parameters:
+// XScale, X0, Offset, Stride and SkipNonFinite.
+// Without options, it is the same as PlotScatterV(label, values, 1, 0).
 //
 // Default marker is ImPlotMarker_Circle.
-func PlotScatter(label string, values interface{}) {
-	PlotScatterV(label, values, 1, 0)
+func PlotScatter[T Number](label string, values []T, opts ...Option) {
+	o := applyOptions(opts)
+	validateLock("PlotScatter")
+	if usePoints[T](&o) {
+		xp, yp, count, stride := wrapPointSlice(finitePoints(nil, values, &o))
+		C.igpPlotScatterXY(C.igpDataType_Double, wrapString(label), xp, yp, count, 0, stride)
+		return
+	}
+	typ, vp, count, offset, stride := wrapNumberSliceOpts(values, &o)
+	C.igpPlotScatter(typ, wrapString(label), vp, count, C.double(o.xscale), C.double(o.x0), offset, stride)
 }
 
 // PlotScatterV plots a standard 2D scatter plot with all parameters.
 //
 // Default marker is ImPlotMarker_Circle.
-func PlotScatterV(label string, values interface{}, xscale, x0 float64) {
-	vd := valueGet(values)
-	C.igpPlotScatter(wrapString(label), wrapDoubleSlice(vd), C.int(len(vd)), C.double(xscale), C.double(x0))
+func PlotScatterV[T Number](label string, values []T, xscale, x0 float64) {
+	validateLock("PlotScatterV")
+	if current().scaled {
+		xp, yp, count, stride := wrapScaledPoints(nil, values, valueOptions(xscale, x0), false)
+		C.igpPlotScatterXY(C.igpDataType_Double, wrapString(label), xp, yp, count, 0, stride)
+		return
+	}
+	typ, vp, count, stride := wrapNumberSlice(values)
+	C.igpPlotScatter(typ, wrapString(label), vp, count, C.double(xscale), C.double(x0), 0, stride)
 }
 
 // PlotScatterP plots a standard 2D scatter plot from a slice of points.
 //
 // Default marker is ImPlotMarker_Circle.
 func PlotScatterP(label string, points []Point) {
-	xp, yp, count, stride := wrapPointSlice(points)
-	C.igpPlotScatterXY(wrapString(label), xp, yp, count, stride)
+	validateLock("PlotScatterP")
+	xp, yp, count, offset, stride := wrapPointSliceScaled(points, 0)
+	C.igpPlotScatterXY(C.igpDataType_Double, wrapString(label), xp, yp, count, offset, stride)
 }
 
 // PlotScatterXY plots a standard 2D scatter plot from slices of X/Y coords.
 //
 // Default marker is ImPlotMarker_Circle.
-func PlotScatterXY(label string, xs, ys interface{}) {
-	xp, yp, count, stride := wrapXYSlice(valueGet(xs), valueGet(ys))
-	C.igpPlotScatterXY(wrapString(label), xp, yp, count, stride)
+func PlotScatterXY[T Number](label string, xs, ys []T) {
+	validateLock("PlotScatterXY")
+	typ, xp, yp, count, stride := wrapXYSliceScaled(xs, ys)
+	C.igpPlotScatterXY(typ, wrapString(label), xp, yp, count, 0, stride)
 }
 
 // PlotScatterG plots a standard 2D scatter plot from a series of points obtained from a callback.
 //
 // Default marker is ImPlotMarker_Circle.
 func PlotScatterG(label string, getter DataGetter, userData interface{}, count int) {
-	PlotScatterP(label, DataGet(getter, userData, count))
+	validateLock("PlotScatterG")
+	handle := addDataGetter(scaledGetter(getter), userData)
+	defer removeDataGetter(handle)
+	C.igpPlotScatterG(wrapString(label), handle, C.int(count))
 }
 
 // PlotStairs
 
-// PlotStairs plots a stairstep graph with minimal parameters.
-// It calls PlotStairsV(label, values, 1, 0).
+// PlotStairs plots a stairstep graph with optional parameters:
+// XScale, X0, Offset, Stride and SkipNonFinite.
+// Without options, it is the same as PlotStairsV(label, values, 1, 0).
 //
 // The y value is continued constantly from every x position,
 // i.e. the interval [x[i], x[i+1]) has the value y[i].
-func PlotStairs(label string, values interface{}) {
-	PlotStairsV(label, values, 1, 0)
+func PlotStairs[T Number](label string, values []T, opts ...Option) {
+	o := applyOptions(opts)
+	validateLock("PlotStairs")
+	if usePoints[T](&o) {
+		xp, yp, count, stride := wrapPointSlice(finitePoints(nil, values, &o))
+		C.igpPlotStairsXY(C.igpDataType_Double, wrapString(label), xp, yp, count, 0, stride)
+		return
+	}
+	typ, vp, count, offset, stride := wrapNumberSliceOpts(values, &o)
+	C.igpPlotStairs(typ, wrapString(label), vp, count, C.double(o.xscale), C.double(o.x0), offset, stride)
 }
 
 // PlotStairsV plots a stairstep graph with all parameters.
@@ -218,9 +261,15 @@ func PlotStairs(label string, values interface{}) {
 //
 // The y value is continued constantly from every x position,
 // i.e. the interval [x[i], x[i+1]) has the value y[i].
-func PlotStairsV(label string, values interface{}, xscale, x0 float64) {
-	vd := valueGet(values)
-	C.igpPlotStairs(wrapString(label), wrapDoubleSlice(vd), C.int(len(vd)), C.double(xscale), C.double(x0))
+func PlotStairsV[T Number](label string, values []T, xscale, x0 float64) {
+	validateLock("PlotStairsV")
+	if current().scaled {
+		xp, yp, count, stride := wrapScaledPoints(nil, values, valueOptions(xscale, x0), false)
+		C.igpPlotStairsXY(C.igpDataType_Double, wrapString(label), xp, yp, count, 0, stride)
+		return
+	}
+	typ, vp, count, stride := wrapNumberSlice(values)
+	C.igpPlotStairs(typ, wrapString(label), vp, count, C.double(xscale), C.double(x0), 0, stride)
 }
 
 // PlotStairsP plots a stairstep graph from a slice of points.
@@ -228,17 +277,19 @@ func PlotStairsV(label string, values interface{}, xscale, x0 float64) {
 // The y value is continued constantly from every x position,
 // i.e. the interval [x[i], x[i+1]) has the value y[i].
 func PlotStairsP(label string, points []Point) {
-	xp, yp, count, stride := wrapPointSlice(points)
-	C.igpPlotStairsXY(wrapString(label), xp, yp, count, stride)
+	validateLock("PlotStairsP")
+	xp, yp, count, offset, stride := wrapPointSliceScaled(points, 0)
+	C.igpPlotStairsXY(C.igpDataType_Double, wrapString(label), xp, yp, count, offset, stride)
 }
 
 // PlotStairsXY plots a stairstep graph from slices of X/Y coords.
 //
 // The y value is continued constantly from every x position,
 // i.e. the interval [x[i], x[i+1]) has the value y[i].
-func PlotStairsXY(label string, xs, ys interface{}) {
-	xp, yp, count, stride := wrapXYSlice(valueGet(xs), valueGet(ys))
-	C.igpPlotStairsXY(wrapString(label), xp, yp, count, stride)
+func PlotStairsXY[T Number](label string, xs, ys []T) {
+	validateLock("PlotStairsXY")
+	typ, xp, yp, count, stride := wrapXYSliceScaled(xs, ys)
+	C.igpPlotStairsXY(typ, wrapString(label), xp, yp, count, 0, stride)
 }
 
 // PlotStairsG plots a stairstep graph from a series of points obtained from a callback.
@@ -246,197 +297,298 @@ func PlotStairsXY(label string, xs, ys interface{}) {
 // The y value is continued constantly from every x position,
 // i.e. the interval [x[i], x[i+1]) has the value y[i].
 func PlotStairsG(label string, getter DataGetter, userData interface{}, count int) {
-	PlotStairsP(label, DataGet(getter, userData, count))
+	validateLock("PlotStairsG")
+	handle := addDataGetter(scaledGetter(getter), userData)
+	defer removeDataGetter(handle)
+	C.igpPlotStairsG(wrapString(label), handle, C.int(count))
 }
 
 // PlotShaded
 // PlotShadedRef
 
-// PlotShadedRef plots a shaded (filled) region between a line and a horizontal reference.
-// It calls PlotShadedV(label, values, 0, 1, 0).
-func PlotShadedRef(label string, values interface{}) {
-	PlotShadedRefV(label, values, 0, 1, 0)
+// PlotShadedRef plots a shaded (filled) region between a line and a horizontal reference,
+// with optional parameters: YRef, XScale, X0, Offset, Stride and SkipNonFinite.
+// Without options, it is the same as PlotShadedRefV(label, values, 0, 1, 0).
+func PlotShadedRef[T Number](label string, values []T, opts ...Option) {
+	o := applyOptions(opts)
+	validateLock("PlotShadedRef")
+	if usePoints[T](&o) {
+		xp, yp, count, stride := wrapPointSlice(finitePoints(nil, values, &o))
+		C.igpPlotShadedRefXY(C.igpDataType_Double, wrapString(label), xp, yp, count, C.double(ScaleValue(Axis_Y1, o.yref)), 0, stride)
+		return
+	}
+	typ, vp, count, offset, stride := wrapNumberSliceOpts(values, &o)
+	C.igpPlotShadedRef(typ, wrapString(label), vp, count, C.double(o.yref), C.double(o.xscale), C.double(o.x0), offset, stride)
 }
 
 // PlotShadedRefV plots a shaded (filled) region between a line and a horizontal reference.
 //
 // Set yref to +/-INFINITY for infinite fill extents.
-func PlotShadedRefV(label string, values interface{}, yref, xscale, x0 float64) {
-	vd := valueGet(values)
-	C.igpPlotShadedRef(wrapString(label), wrapDoubleSlice(vd), C.int(len(vd)), C.double(yref), C.double(xscale), C.double(x0))
+func PlotShadedRefV[T Number](label string, values []T, yref, xscale, x0 float64) {
+	validateLock("PlotShadedRefV")
+	if current().scaled {
+		xp, yp, count, stride := wrapScaledPoints(nil, values, valueOptions(xscale, x0), false)
+		C.igpPlotShadedRefXY(C.igpDataType_Double, wrapString(label), xp, yp, count, C.double(ScaleValue(Axis_Y1, yref)), 0, stride)
+		return
+	}
+	typ, vp, count, stride := wrapNumberSlice(values)
+	C.igpPlotShadedRef(typ, wrapString(label), vp, count, C.double(yref), C.double(xscale), C.double(x0), 0, stride)
 }
 
 // PlotShadedRefP plots a shaded (filled) region between a line and a horizontal reference.
 //
 // Set yref to +/-INFINITY for infinite fill extents.
 func PlotShadedRefP(label string, points []Point, yref float64) {
-	xp, yp, count, stride := wrapPointSlice(points)
-	C.igpPlotShadedRefXY(wrapString(label), xp, yp, C.double(yref), count, stride)
+	validateLock("PlotShadedRefP")
+	xp, yp, count, offset, stride := wrapPointSliceScaled(points, 0)
+	C.igpPlotShadedRefXY(C.igpDataType_Double, wrapString(label), xp, yp, count, C.double(ScaleValue(Axis_Y1, yref)), offset, stride)
 }
 
 // PlotShadedRefXY plots a shaded (filled) region between a line and a horizontal reference.
 //
 // Set yref to +/-INFINITY for infinite fill extents.
-func PlotShadedRefXY(label string, xs, ys interface{}, yref float64) {
-	xp, yp, count, stride := wrapXYSlice(valueGet(xs), valueGet(ys))
-	C.igpPlotShadedRefXY(wrapString(label), xp, yp, C.double(yref), count, stride)
+func PlotShadedRefXY[T Number](label string, xs, ys []T, yref float64) {
+	validateLock("PlotShadedRefXY")
+	typ, xp, yp, count, stride := wrapXYSliceScaled(xs, ys)
+	C.igpPlotShadedRefXY(typ, wrapString(label), xp, yp, count, C.double(ScaleValue(Axis_Y1, yref)), 0, stride)
 }
 
 // PlotShadedRefG plots a shaded (filled) region between a line and a horizontal reference.
 //
 // Set yref to +/-INFINITY for infinite fill extents.
 func PlotShadedRefG(label string, getter DataGetter, userData interface{}, count int, yref float64) {
-	PlotShadedRefP(label, DataGet(getter, userData, count), yref)
+	validateLock("PlotShadedRefG")
+	handle := addDataGetter(scaledGetter(getter), userData)
+	defer removeDataGetter(handle)
+	C.igpPlotShadedRefG(wrapString(label), handle, C.int(count), C.double(ScaleValue(Axis_Y1, yref)))
 }
 
 // PlotShadedLines
 
-// PlotShadedLines plots a shaded (filled) region between two lines, without the lines themselves.
-// It calls PlotShadedLinesV(label, vs0, vs1, 1, 0).
-func PlotShadedLines(label string, vs0, vs1 interface{}) {
-	PlotShadedLinesV(label, vs0, vs1, 1, 0)
+// PlotShadedLines plots a shaded (filled) region between two lines, without the lines themselves,
+// with optional parameters: XScale, X0, Offset and Stride.
+// Without options, it is the same as PlotShadedLinesV(label, vs0, vs1, 1, 0).
+func PlotShadedLines[T Number](label string, vs0, vs1 []T, opts ...Option) {
+	o := applyOptions(opts)
+	validateLock("PlotShadedLines")
+	if current().scaled {
+		xp, yp1, yp2, count, stride := wrapShadedScaled(nil, vs0, vs1, &o)
+		C.igpPlotShadedLinesXY(C.igpDataType_Double, wrapString(label), xp, yp1, yp2, count, 0, stride)
+		return
+	}
+	typ, vp0, vp1, count, stride := wrapXYSlice(vs0, vs1)
+	count, offset, stride := o.strided(count, stride)
+	C.igpPlotShadedLines(typ, wrapString(label), vp0, vp1, count, C.double(o.xscale), C.double(o.x0), offset, stride)
 }
 
 // PlotShadedLinesV plots a shaded (filled) region between two lines, without the lines themselves.
-func PlotShadedLinesV(label string, vs0, vs1 interface{}, xscale, x0 float64) {
-	vd0, vd1 := valueGet(vs0), valueGet(vs1)
-	n := minint(len(vd0), len(vd1))
-	// Constuct the X coords
-	xs := make([]float64, n)
-	for i := 0; i < n; i++ {
-		xs[i] = x0 + (float64)(i)*xscale
+func PlotShadedLinesV[T Number](label string, vs0, vs1 []T, xscale, x0 float64) {
+	validateLock("PlotShadedLinesV")
+	if current().scaled {
+		xp, yp1, yp2, count, stride := wrapShadedScaled(nil, vs0, vs1, valueOptions(xscale, x0))
+		C.igpPlotShadedLinesXY(C.igpDataType_Double, wrapString(label), xp, yp1, yp2, count, 0, stride)
+		return
 	}
-	PlotShadedLinesXY(label, xs, vd0, vd1)
+	typ, vp0, vp1, count, stride := wrapXYSlice(vs0, vs1)
+	C.igpPlotShadedLines(typ, wrapString(label), vp0, vp1, count, C.double(xscale), C.double(x0), 0, stride)
 }
 
 // PlotShadedLinesXY plots a shaded (filled) region between two lines, without the lines themselves.
-func PlotShadedLinesXY(label string, xs, ys1, ys2 interface{}) {
-	xd, yd1, yd2 := valueGet(xs), valueGet(ys1), valueGet(ys2)
-	C.igpPlotShadedLinesXY(
-		wrapString(label),
-		wrapDoubleSlice(xd),
-		wrapDoubleSlice(yd1),
-		wrapDoubleSlice(yd2),
-		C.int(minint(len(xd), minint(len(yd1), len(yd2)))),
-		C.int(unsafe.Sizeof(float64(0))),
-	)
+func PlotShadedLinesXY[T Number](label string, xs, ys1, ys2 []T) {
+	validateLock("PlotShadedLinesXY")
+	if current().scaled {
+		xp, yp1, yp2, count, stride := wrapShadedScaled(xs, ys1, ys2, valueOptions(1, 0))
+		C.igpPlotShadedLinesXY(C.igpDataType_Double, wrapString(label), xp, yp1, yp2, count, 0, stride)
+		return
+	}
+	n := minint(len(xs), minint(len(ys1), len(ys2)))
+	typ, xp, _, count, stride := wrapXYSlice(xs[:n], ys1[:n])
+	_, yp1, yp2, _, _ := wrapXYSlice(ys1[:n], ys2[:n])
+	C.igpPlotShadedLinesXY(typ, wrapString(label), xp, yp1, yp2, count, 0, stride)
 }
 
 // PlotShadedLinesG plots a shaded (filled) region between two lines, without the lines themselves.
 //
 // The X component of the second getter is discarded.
 func PlotShadedLinesG(label string, get1 DataGetter, data1 interface{}, get2 DataGetter, data2 interface{}, count int) {
-	xs, ys1, ys2 := make([]float64, count), make([]float64, count), make([]float64, count)
-	for i := 0; i < count; i++ {
-		pt1 := get1(data1, i)
-		xs[i], ys1[i] = pt1.X, pt1.Y
-		pt2 := get2(data2, i)
-		_, ys2[i] = pt2.X, pt2.Y
-	}
-
-	PlotShadedLinesXY(label, xs, ys1, ys2)
+	validateLock("PlotShadedLinesG")
+	handle1 := addDataGetter(scaledGetter(get1), data1)
+	defer removeDataGetter(handle1)
+	handle2 := addDataGetter(scaledGetter(get2), data2)
+	defer removeDataGetter(handle2)
+	C.igpPlotShadedLinesG(wrapString(label), handle1, handle2, C.int(count))
 }
 
 // PlotBars
 
-// PlotBars plots a vertical bar graph, with every bar centering at X coords 0, 1, ..., N-1.
-func PlotBars(label string, vs interface{}) {
-	PlotBarsV(label, vs, 0.67, 0)
+// PlotBars plots a vertical bar graph, with every bar centering at X coords 0, 1, ..., N-1,
+// with optional parameters: BarWidth, X0, Offset and Stride.
+// Without options, it is the same as PlotBarsV(label, vs, 0.67, 0).
+func PlotBars[T Number](label string, vs []T, opts ...Option) {
+	o := applyOptions(opts)
+	validateLock("PlotBars")
+	if current().scaled {
+		o.xscale = 1
+		xp, yp, count, stride := wrapScaledPoints(nil, vs, &o, false)
+		C.igpPlotBarsXY(C.igpDataType_Double, wrapString(label), xp, yp, count, C.double(o.barWidth), 0, stride)
+		return
+	}
+	typ, vp, count, offset, stride := wrapNumberSliceOpts(vs, &o)
+	C.igpPlotBars(typ, wrapString(label), vp, count, C.double(o.barWidth), C.double(o.x0), offset, stride)
 }
 
 // PlotBarsV plots a vertical bar graph, with bars centering at
 // x0, x0+1, x0+2, ... x0+N-1, Each taking up a fraction of the
 // available width. #barWidth should be in (0, 1].
-func PlotBarsV(label string, vs interface{}, barWidth, x0 float64) {
-	vd := valueGet(vs)
-	C.igpPlotBars(wrapString(label), wrapDoubleSlice(vd), C.int(len(vd)), C.double(barWidth), C.double(x0))
+func PlotBarsV[T Number](label string, vs []T, barWidth, x0 float64) {
+	validateLock("PlotBarsV")
+	if current().scaled {
+		xp, yp, count, stride := wrapScaledPoints(nil, vs, valueOptions(1, x0), false)
+		C.igpPlotBarsXY(C.igpDataType_Double, wrapString(label), xp, yp, count, C.double(barWidth), 0, stride)
+		return
+	}
+	typ, vp, count, stride := wrapNumberSlice(vs)
+	C.igpPlotBars(typ, wrapString(label), vp, count, C.double(barWidth), C.double(x0), 0, stride)
 }
 
 // PlotBarsP plots a vertical bar graph, with bars each taking up a
 // fraction of the available width. #barWidthFraction should be in (0, 1].
 func PlotBarsP(label string, ps []Point, barWidth float64) {
-	xp, yp, count, stride := wrapPointSlice(ps)
-	C.igpPlotBarsXY(wrapString(label), xp, yp, count, C.double(barWidth), stride)
+	validateLock("PlotBarsP")
+	xp, yp, count, offset, stride := wrapPointSliceScaled(ps, 0)
+	C.igpPlotBarsXY(C.igpDataType_Double, wrapString(label), xp, yp, count, C.double(barWidth), offset, stride)
 }
 
 // PlotBarsXY plots a vertical bar graph, with bars each taking up a
 // fraction of the available width. #barWidth should be in (0, 1].
-func PlotBarsXY(label string, vx, vy interface{}, barWidth float64) {
-	xp, yp, count, stride := wrapXYSlice(valueGet(vx), valueGet(vy))
-	C.igpPlotBarsXY(wrapString(label), xp, yp, count, C.double(barWidth), stride)
+func PlotBarsXY[T Number](label string, vx, vy []T, barWidth float64) {
+	validateLock("PlotBarsXY")
+	typ, xp, yp, count, stride := wrapXYSliceScaled(vx, vy)
+	C.igpPlotBarsXY(typ, wrapString(label), xp, yp, count, C.double(barWidth), 0, stride)
 }
 
 // PlotBarsG plots a vertical bar graph, with bars each taking up a
 // fraction of the available width. #barWidth should be in (0, 1].
 func PlotBarsG(label string, getter DataGetter, userData interface{}, count int, barWidth float64) {
-	PlotBarsP(label, DataGet(getter, userData, count), barWidth)
-}
-
-// PlotBarsH plots a horizontal bar graph, with every bar centering at Y coords 0, 1, ..., N-1.
-func PlotBarsH(label string, vs interface{}) {
-	PlotBarsHV(label, vs, 0.67, 0)
+	validateLock("PlotBarsG")
+	handle := addDataGetter(scaledGetter(getter), userData)
+	defer removeDataGetter(handle)
+	C.igpPlotBarsG(wrapString(label), handle, C.int(count), C.double(barWidth))
+}
+
+// PlotBarsH plots a horizontal bar graph, with every bar centering at Y coords 0, 1, ..., N-1,
+// with optional parameters: BarWidth (as the bar height), X0 (as y0), Offset and Stride.
+// Without options, it is the same as PlotBarsHV(label, vs, 0.67, 0).
+func PlotBarsH[T Number](label string, vs []T, opts ...Option) {
+	o := applyOptions(opts)
+	validateLock("PlotBarsH")
+	if current().scaled {
+		o.xscale = 1
+		xp, yp, count, stride := wrapScaledPoints(nil, vs, &o, true)
+		C.igpPlotBarsHXY(C.igpDataType_Double, wrapString(label), xp, yp, count, C.double(o.barWidth), 0, stride)
+		return
+	}
+	typ, vp, count, offset, stride := wrapNumberSliceOpts(vs, &o)
+	C.igpPlotBarsH(typ, wrapString(label), vp, count, C.double(o.barWidth), C.double(o.x0), offset, stride)
 }
 
 // PlotBarsHV plots a horizontal bar graph, with bars centering at
 // y0, y0+1, y0+2, ... y0+N-1, Each taking up a fraction of the
 // available height. #barHeight should be in (0, 1].
-func PlotBarsHV(label string, vs interface{}, barHeight, y0 float64) {
-	vd := valueGet(vs)
-	C.igpPlotBarsH(wrapString(label), wrapDoubleSlice(vd), C.int(len(vd)), C.double(barHeight), C.double(y0))
+func PlotBarsHV[T Number](label string, vs []T, barHeight, y0 float64) {
+	validateLock("PlotBarsHV")
+	if current().scaled {
+		xp, yp, count, stride := wrapScaledPoints(nil, vs, valueOptions(1, y0), true)
+		C.igpPlotBarsHXY(C.igpDataType_Double, wrapString(label), xp, yp, count, C.double(barHeight), 0, stride)
+		return
+	}
+	typ, vp, count, stride := wrapNumberSlice(vs)
+	C.igpPlotBarsH(typ, wrapString(label), vp, count, C.double(barHeight), C.double(y0), 0, stride)
 }
 
 // PlotBarsHP plots a horizontal bar graph, with bars each taking up a
 // fraction of the available height. #barHeight should be in (0, 1].
 func PlotBarsHP(label string, ps []Point, barHeight float64) {
-	xp, yp, count, stride := wrapPointSlice(ps)
-	C.igpPlotBarsHXY(wrapString(label), xp, yp, count, C.double(barHeight), stride)
+	validateLock("PlotBarsHP")
+	xp, yp, count, offset, stride := wrapPointSliceScaled(ps, 0)
+	C.igpPlotBarsHXY(C.igpDataType_Double, wrapString(label), xp, yp, count, C.double(barHeight), offset, stride)
 }
 
 // PlotBarsHXY plots a horizontal bar graph, with bars each taking up a
 // fraction of the available height. #barHeight should be in (0, 1].
-func PlotBarsHXY(label string, vx, vy interface{}, barHeight float64) {
-	xp, yp, count, stride := wrapXYSlice(valueGet(vx), valueGet(vy))
-	C.igpPlotBarsHXY(wrapString(label), xp, yp, count, C.double(barHeight), stride)
+func PlotBarsHXY[T Number](label string, vx, vy []T, barHeight float64) {
+	validateLock("PlotBarsHXY")
+	typ, xp, yp, count, stride := wrapXYSliceScaled(vx, vy)
+	C.igpPlotBarsHXY(typ, wrapString(label), xp, yp, count, C.double(barHeight), 0, stride)
 }
 
 // PlotBarsHG plots a horizontal bar graph, with bars each taking up a
 // fraction of the available height. #barHeight should be in (0, 1].
 func PlotBarsHG(label string, getter DataGetter, userData interface{}, count int, barHeight float64) {
-	PlotBarsHP(label, DataGet(getter, userData, count), barHeight)
+	validateLock("PlotBarsHG")
+	handle := addDataGetter(scaledGetter(getter), userData)
+	defer removeDataGetter(handle)
+	C.igpPlotBarsHG(wrapString(label), handle, C.int(count), C.double(barHeight))
 }
 
-// PlotBarGroups plots a group of vertical bars.
-//
-// The I-th item in the J-th group is in #values[I][J].
-// The I-th item has a legend label of #itemLabels[I].
+// wrapBarGroups copies the first n rows and m columns of #values
+// into a row-major matrix in C memory, freed after EndPlot.
 //
-// Item count  N = Min(len(itemLabels), len(values)).
-// Group count M = Min(len(values[0]), len(values[1]), ... len(values[N-1])).
-//
-// The bar groups are centered at at x0, x0+1, x0+2, x0+M-1.
-// If you want to put labels on the groups, use SetupAxisTickValues.
-func PlotBarGroups(itemLabels []string, values [][]float64, groupWidth, x0 float64, flags BarGroupsFlags) {
-	n, m := minint(len(itemLabels), len(values)), math.MaxInt
-	for _, s := range values {
+// The values are transformed by the scale of #valueAxis, if any. The groups
+// are placed by ImPlot, so #call panics if #posAxis has a scale.
+func wrapBarGroups[T Number](call string, itemLabels []string, values [][]T, posAxis, valueAxis Axis) (typ C.igpDataType, vp unsafe.Pointer, vplabels **C.char, n, m int) {
+	n, m = minint(len(itemLabels), len(values)), math.MaxInt
+	for _, s := range values[:n] {
 		m = minint(m, len(s))
 	}
+	if n == 0 {
+		m = 0
+	}
+
+	s := current()
+	if s.scales[posAxis] != nil {
+		panic(fmt.Errorf("implot: %s: the groups cannot be placed on an axis with a scale: %w", call, ErrScaleUnsupported))
+	}
+	vplabels = wrapStringSlice(itemLabels)
+	if t := s.scales[valueAxis]; t != nil {
+		typ = C.igpDataType_Double
+		vp = s.arena.alloc(n*m*8, 8)
+		matrix := unsafe.Slice((*float64)(vp), n*m)
+		for i := 0; i < n; i++ {
+			for j := 0; j < m; j++ {
+				matrix[i*m+j] = t.Forward(float64(values[i][j]))
+			}
+		}
+		return
+	}
 
 	// Construct the matrix
-	stride := unsafe.Sizeof(C.double(0))
-	vp := C.malloc(C.size_t(uintptr(n*m) * stride))
+	typ = dataTypeOf[T]()
+	stride := unsafe.Sizeof(*new(T))
+	vp = s.arena.alloc(n*m*int(stride), int(stride))
 	for i := 0; i < n; i++ {
 		for j := 0; j < m; j++ {
-			*((*float64)(unsafe.Add(vp, stride*uintptr(i*m+j)))) = values[i][j]
+			*((*T)(unsafe.Add(vp, stride*uintptr(i*m+j)))) = values[i][j]
 		}
 	}
-	addEndPlotCb(func() { C.free(vp) })
-
-	// Copy the labels
-	vplabels, fin := wrapStringSlice(itemLabels)
-	addEndPlotCb(fin)
+	return
+}
 
-	// Make the call
-	C.igpPlotBarGroups(vplabels, (*C.double)(vp), C.int(n), C.int(m), C.double(groupWidth), C.double(x0), C.igpBarGroupsFlags(flags))
+// PlotBarGroups plots a group of vertical bars.
+//
+// The I-th item in the J-th group is in #values[I][J].
+// The I-th item has a legend label of #itemLabels[I].
+//
+// Item count  N = Min(len(itemLabels), len(values)).
+// Group count M = Min(len(values[0]), len(values[1]), ... len(values[N-1])).
+//
+// The bar groups are centered at at x0, x0+1, x0+2, x0+M-1.
+// If you want to put labels on the groups, use SetupAxisTickValues.
+// It panics if the X axis has a scale set by SetupAxisScale.
+func PlotBarGroups[T Number](itemLabels []string, values [][]T, groupWidth, x0 float64, flags BarGroupsFlags) {
+	validateLock("PlotBarGroups")
+	typ, vp, vplabels, n, m := wrapBarGroups("PlotBarGroups", itemLabels, values, Axis_X1, Axis_Y1)
+	C.igpPlotBarGroups(typ, vplabels, vp, C.int(n), C.int(m), C.double(groupWidth), C.double(x0), C.igpBarGroupsFlags(flags))
 }
 
 // PlotBarGroupsH plots a group of horizontal bars.
@@ -449,26 +601,18 @@ func PlotBarGroups(itemLabels []string, values [][]float64, groupWidth, x0 float
 //
 // The bar groups are centered at at y0, y0+1, y0+2, y0+M-1.
 // If you want to put labels on the groups, use SetupAxisTickValues.
-func PlotBarGroupsH(itemLabels []string, values [][]float64, groupWidth, y0 float64, flags BarGroupsFlags) {
-	n, m := minint(len(itemLabels), len(values)), math.MaxInt
-	for _, s := range values {
-		m = minint(m, len(s))
-	}
-
-	// Construct the matrix
-	stride := unsafe.Sizeof(C.double(0))
-	vp := C.malloc(C.size_t(uintptr(n*m) * stride))
-	for i := 0; i < n; i++ {
-		for j := 0; j < m; j++ {
-			*((*float64)(unsafe.Add(vp, stride*uintptr(i*m+j)))) = values[i][j]
-		}
-	}
-	addEndPlotCb(func() { C.free(vp) })
-
-	// Copy the labels
-	vplabels, fin := wrapStringSlice(itemLabels)
-	addEndPlotCb(fin)
+// It panics if the Y axis has a scale set by SetupAxisScale.
+func PlotBarGroupsH[T Number](itemLabels []string, values [][]T, groupWidth, y0 float64, flags BarGroupsFlags) {
+	validateLock("PlotBarGroupsH")
+	typ, vp, vplabels, n, m := wrapBarGroups("PlotBarGroupsH", itemLabels, values, Axis_Y1, Axis_X1)
+	C.igpPlotBarGroupsH(typ, vplabels, vp, C.int(n), C.int(m), C.double(groupWidth), C.double(y0), C.igpBarGroupsFlags(flags))
+}
 
-	// Make the call
-	C.igpPlotBarGroupsH(vplabels, (*C.double)(vp), C.int(n), C.int(m), C.double(groupWidth), C.double(y0), C.igpBarGroupsFlags(flags))
+// Annotation shows a text label at a point of the plot, #offset pixels away from it.
+// The background is #color, or transparent with the inlay text color if its alpha is 0.
+//
+// If #clamp is set, the label is kept inside the plot area.
+func Annotation(x, y float64, color imgui.Vec4, offset imgui.Vec2, clamp bool, text string) {
+	validateLock("Annotation")
+	C.igpAnnotation(C.double(x), C.double(y), wrapVec4(color), wrapVec2(offset), C.bool(clamp), wrapString(text))
 }
@@ -0,0 +1,103 @@
+package implot
+
+// #include "wrapper/Plot.h"
+import "C"
+
+// RingBuffer is a fixed-capacity circular buffer of Points, for scrolling plots.
+//
+// Once full, every Push overwrites the oldest point. The PlotXXXRing
+// functions plot the points oldest-first in place, using the offset
+// parameter of ImPlot, so no copy or allocation happens per frame.
+//
+// RingBuffer is not safe for concurrent use.
+type RingBuffer struct {
+	data   []Point
+	offset int // index of the oldest point once the buffer is full
+}
+
+// NewRingBuffer creates a RingBuffer holding at most #capacity points.
+func NewRingBuffer(capacity int) *RingBuffer {
+	if capacity <= 0 {
+		panic("NewRingBuffer called with a non-positive capacity")
+	}
+	return &RingBuffer{data: make([]Point, 0, capacity)}
+}
+
+// Push adds a point, overwriting the oldest one if the buffer is full.
+func (b *RingBuffer) Push(p Point) {
+	if len(b.data) < cap(b.data) {
+		b.data = append(b.data, p)
+	} else {
+		b.data[b.offset] = p
+		b.offset = (b.offset + 1) % len(b.data)
+	}
+}
+
+// PushXY adds a point from its coordinates.
+func (b *RingBuffer) PushXY(x, y float64) {
+	b.Push(Point{X: x, Y: y})
+}
+
+// Len returns the number of points in the buffer.
+func (b *RingBuffer) Len() int { return len(b.data) }
+
+// Cap returns the maximum number of points in the buffer.
+func (b *RingBuffer) Cap() int { return cap(b.data) }
+
+// At returns the i-th oldest point, i in [0, Len()).
+func (b *RingBuffer) At(i int) Point {
+	return b.data[(b.offset+i)%len(b.data)]
+}
+
+// Last returns the newest point. It panics if the buffer is empty.
+func (b *RingBuffer) Last() Point {
+	return b.At(len(b.data) - 1)
+}
+
+// Clear empties the buffer, keeping its capacity.
+func (b *RingBuffer) Clear() {
+	b.data = b.data[:0]
+	b.offset = 0
+}
+
+// Data returns the underlying storage and the index of the oldest point in it.
+// The points are in order data[offset:], then data[:offset].
+func (b *RingBuffer) Data() (data []Point, offset int) {
+	return b.data, b.offset
+}
+
+// PlotLineRing plots a standard 2D line plot from a RingBuffer, oldest point first.
+func PlotLineRing(label string, b *RingBuffer) {
+	validateLock("PlotLineRing")
+	xp, yp, count, offset, stride := wrapPointSliceScaled(b.data, b.offset)
+	C.igpPlotLineXY(C.igpDataType_Double, wrapString(label), xp, yp, count, offset, stride)
+}
+
+// PlotScatterRing plots a standard 2D scatter plot from a RingBuffer, oldest point first.
+//
+// Default marker is ImPlotMarker_Circle.
+func PlotScatterRing(label string, b *RingBuffer) {
+	validateLock("PlotScatterRing")
+	xp, yp, count, offset, stride := wrapPointSliceScaled(b.data, b.offset)
+	C.igpPlotScatterXY(C.igpDataType_Double, wrapString(label), xp, yp, count, offset, stride)
+}
+
+// PlotStairsRing plots a stairstep graph from a RingBuffer, oldest point first.
+//
+// The y value is continued constantly from every x position,
+// i.e. the interval [x[i], x[i+1]) has the value y[i].
+func PlotStairsRing(label string, b *RingBuffer) {
+	validateLock("PlotStairsRing")
+	xp, yp, count, offset, stride := wrapPointSliceScaled(b.data, b.offset)
+	C.igpPlotStairsXY(C.igpDataType_Double, wrapString(label), xp, yp, count, offset, stride)
+}
+
+// PlotShadedRefRing plots a shaded (filled) region between a line and a horizontal reference,
+// from a RingBuffer, oldest point first.
+//
+// Set yref to +/-INFINITY for infinite fill extents.
+func PlotShadedRefRing(label string, b *RingBuffer, yref float64) {
+	validateLock("PlotShadedRefRing")
+	xp, yp, count, offset, stride := wrapPointSliceScaled(b.data, b.offset)
+	C.igpPlotShadedRefXY(C.igpDataType_Double, wrapString(label), xp, yp, count, C.double(ScaleValue(Axis_Y1, yref)), offset, stride)
+}
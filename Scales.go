@@ -0,0 +1,261 @@
+package implot
+
+// #include "wrapper/Types.h"
+import "C"
+import (
+	"errors"
+	"math"
+	"strconv"
+	"unsafe"
+)
+
+// Transform is a custom axis scale, for what AxisFlags_LogScale cannot do
+// (e.g. data around or below zero). Forward maps data values to the linear
+// plot space, and Inverse maps them back. Both must be monotone.
+//
+// ImPlot 0.13 has no custom scales, so they are done on the Go side:
+// set one up with SetupAxisScale, and the data of the plot functions is
+// transformed forward, while the tick labels and the mouse position text
+// are mapped back.
+//
+// The transformed data is copied into a buffer reused by every plot, and
+// plotted as float64, without the points mapped to NaN or Inf. The data of
+// the PlotXXXG getters is transformed as it is got, and not filtered.
+// Sizes in plot units, like the widths of bars, are in the transformed space.
+//
+// A Batch cannot be replayed and PlotCandlestick returns ErrScaleUnsupported
+// on a plot with scales; the positions of PlotBarGroups cannot be scaled.
+type Transform struct {
+	Forward, Inverse func(float64) float64
+}
+
+// SymLog returns a symmetric log scale: linear in [-linthresh, linthresh]
+// and logarithmic outside of it, so it handles zero and negative values.
+// The two parts join smoothly: +/-linthresh map to +/-1, and every factor
+// of e beyond them adds 1.
+func SymLog(linthresh float64) Transform {
+	if !(linthresh > 0) {
+		linthresh = 1
+	}
+	return Transform{
+		Forward: func(v float64) float64 {
+			if a := math.Abs(v); a > linthresh {
+				return math.Copysign(1+math.Log(a/linthresh), v)
+			}
+			return v / linthresh
+		},
+		Inverse: func(v float64) float64 {
+			if a := math.Abs(v); a > 1 {
+				return math.Copysign(linthresh*math.Exp(a-1), v)
+			}
+			return v * linthresh
+		},
+	}
+}
+
+// Logit returns a logit scale for probabilities in (0, 1).
+// 0 and 1 map to -Inf and +Inf, and are skipped.
+func Logit() Transform {
+	return Transform{
+		Forward: func(v float64) float64 { return math.Log(v / (1 - v)) },
+		Inverse: func(v float64) float64 { return 1 / (1 + math.Exp(-v)) },
+	}
+}
+
+// Sqrt returns a square root scale. Negative values are mapped
+// symmetrically, to -sqrt(-v).
+func Sqrt() Transform {
+	return Transform{
+		Forward: func(v float64) float64 { return math.Copysign(math.Sqrt(math.Abs(v)), v) },
+		Inverse: func(v float64) float64 { return math.Copysign(v*v, v) },
+	}
+}
+
+// Forwards appends the forward-transformed #values to #dst, for the
+// functions not transforming their data (PlotXXXV, PlotXXXXY, ...).
+func (t Transform) Forwards(dst, values []float64) []float64 {
+	for _, v := range values {
+		dst = append(dst, t.Forward(v))
+	}
+	return dst
+}
+
+// SetupAxisScale sets a custom scale for an axis of the current plot.
+//
+// The tick labels (and the mouse position text) are formatted by #format
+// from the values mapped back, or like "%g" if #format is nil. Like
+// SetupAxisFormatCallback, it replaces the format of the axis.
+//
+// Only the data on the axes X1 and Y1 is transformed, as they are the ones
+// this package plots on. Use ScaleValue and UnscaleValue to convert other
+// values, like the positions of drag tools and mouse readouts.
+func SetupAxisScale(axis Axis, t Transform, format Formatter, userData interface{}) {
+	validateSetup("SetupAxisScale")
+	s := current()
+	s.scales[axis] = &t
+	s.scaled = s.scales[Axis_X1] != nil || s.scales[Axis_Y1] != nil
+
+	if format == nil {
+		format = func(v float64, _ interface{}) string { return strconv.FormatFloat(v, 'g', 6, 64) }
+	}
+	SetupAxisFormatCallback(axis, func(v float64, userData interface{}) string {
+		return format(t.Inverse(v), userData)
+	}, userData)
+}
+
+// ScaleValue maps a data value to the plot space of an axis of the current
+// plot, with the scale set by SetupAxisScale. Without a scale, it returns #v.
+func ScaleValue(axis Axis, v float64) float64 {
+	if t := current().scales[axis]; t != nil {
+		return t.Forward(v)
+	}
+	return v
+}
+
+// UnscaleValue maps a value in the plot space of an axis of the current plot
+// back to data, with the scale set by SetupAxisScale. Without a scale, it returns #v.
+func UnscaleValue(axis Axis, v float64) float64 {
+	if t := current().scales[axis]; t != nil {
+		return t.Inverse(v)
+	}
+	return v
+}
+
+// ErrScaleUnsupported is returned by the functions which cannot plot on
+// the axes with a scale set by SetupAxisScale.
+var ErrScaleUnsupported = errors.New("custom axis scales are not supported")
+
+// scaledPoints applies the scales of the X1/Y1 axes to #pts in place, and
+// returns them without the points which are not finite.
+func scaledPoints(pts []Point) []Point {
+	s := current()
+	tx, ty := s.scales[Axis_X1], s.scales[Axis_Y1]
+	out := pts[:0]
+	for _, p := range pts {
+		if p, ok := scalePoint(tx, ty, p); ok {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// scalePoint applies the scales #tx and #ty (if not nil) to #p, and returns
+// if the result is finite.
+func scalePoint(tx, ty *Transform, p Point) (Point, bool) {
+	if tx != nil {
+		p.X = tx.Forward(p.X)
+	}
+	if ty != nil {
+		p.Y = ty.Forward(p.Y)
+	}
+	return p, !math.IsNaN(p.X) && !math.IsInf(p.X, 0) && !math.IsNaN(p.Y) && !math.IsInf(p.Y, 0)
+}
+
+// valueOptions returns the options of the PlotXXXV functions.
+func valueOptions(xscale, x0 float64) *options {
+	o := defaultOptions
+	o.xscale, o.x0 = xscale, x0
+	return &o
+}
+
+// wrapScaledPoints copies the points of #xs and #ys (or of the values #ys,
+// if #xs is nil) into the scratch buffer with the options and the scales
+// applied. If #swap is set, X and Y are swapped first, for horizontal bars.
+func wrapScaledPoints[T Number](xs, ys []T, o *options, swap bool) (xp, yp unsafe.Pointer, count, stride C.int) {
+	s := current()
+	pts := gatherPoints(xs, ys, o)
+	if swap {
+		for i := range pts {
+			pts[i].X, pts[i].Y = pts[i].Y, pts[i].X
+		}
+	}
+	s.scratch = scaledPoints(pts)
+	return wrapPointSlice(s.scratch)
+}
+
+// wrapXYSliceScaled is wrapXYSlice, with the points copied and transformed
+// if the current plot has scales.
+func wrapXYSliceScaled[T Number](xs, ys []T) (typ C.igpDataType, xp, yp unsafe.Pointer, count, stride C.int) {
+	if current().scaled {
+		xp, yp, count, stride = wrapScaledPoints(xs, ys, valueOptions(1, 0), false)
+		return C.igpDataType_Double, xp, yp, count, stride
+	}
+	return wrapXYSlice(xs, ys)
+}
+
+// wrapPointSliceScaled is wrapPointSlice with an #offset, with the points
+// copied (from #offset on, wrapping around) and transformed if the current
+// plot has scales.
+func wrapPointSliceScaled(points []Point, offset int) (xp, yp unsafe.Pointer, count, off, stride C.int) {
+	s := current()
+	if !s.scaled {
+		xp, yp, count, stride = wrapPointSlice(points)
+		return xp, yp, count, C.int(offset), stride
+	}
+	pts := append(s.scratch[:0], points[offset:]...)
+	pts = append(pts, points[:offset]...)
+	s.scratch = scaledPoints(pts)
+	xp, yp, count, stride = wrapPointSlice(s.scratch)
+	return xp, yp, count, 0, stride
+}
+
+// wrapShadedScaled copies the X coords and the two Y series of a shaded
+// region into the scratch buffer with the options and the scales applied,
+// interleaved as (x, y1), (x, y2). If #xs is nil, the X coords are
+// x0 + i*xscale. Only the rows with all 3 values finite are kept.
+func wrapShadedScaled[T Number](xs, ys1, ys2 []T, o *options) (xp, yp1, yp2 unsafe.Pointer, count, stride C.int) {
+	n := minint(len(ys1), len(ys2))
+	if xs != nil {
+		n = minint(len(xs), n)
+	}
+	st := o.stride
+	if st < 1 {
+		st = 1
+	}
+	rows := (n + st - 1) / st
+
+	s := current()
+	tx, ty := s.scales[Axis_X1], s.scales[Axis_Y1]
+	pts := s.scratch[:0]
+	if rows > 0 {
+		off := (o.offset%rows + rows) % rows
+		for i := 0; i < rows; i++ {
+			k := ((off + i) % rows) * st
+			x := o.x0 + float64(i)*o.xscale
+			if xs != nil {
+				x = float64(xs[k])
+			}
+			p1, ok1 := scalePoint(tx, ty, Point{X: x, Y: float64(ys1[k])})
+			p2, ok2 := scalePoint(tx, ty, Point{X: x, Y: float64(ys2[k])})
+			if ok1 && ok2 {
+				pts = append(pts, p1, p2)
+			}
+		}
+	}
+	s.scratch = pts
+	if len(pts) == 0 {
+		return
+	}
+	return unsafe.Pointer(&pts[0].X), unsafe.Pointer(&pts[0].Y), unsafe.Pointer(&pts[1].Y),
+		C.int(len(pts) / 2), C.int(2 * unsafe.Sizeof(Point{}))
+}
+
+// scaledGetter returns #getter, wrapped to transform its points if the
+// current plot has scales.
+func scaledGetter(getter DataGetter) DataGetter {
+	s := current()
+	if !s.scaled {
+		return getter
+	}
+	tx, ty := s.scales[Axis_X1], s.scales[Axis_Y1]
+	return func(userData interface{}, idx int) Point {
+		p, _ := scalePoint(tx, ty, getter(userData, idx))
+		return p
+	}
+}
+
+// usePoints returns if the data of a plot must go through finitePoints:
+// to skip the non-finite values, or to apply the scales of the plot.
+func usePoints[T Number](o *options) bool {
+	return (o.skipNonFinite && isFloat[T]()) || current().scaled
+}
@@ -0,0 +1,88 @@
+package implot
+
+import "github.com/inkyblackness/imgui-go/v4"
+
+// The functions below wrap a Begin/End or Push/Pop pair around a function.
+// The End/Pop is deferred, so it is called even if the function panics;
+// the panic then continues on. For example:
+//
+//     implot.Plot("My Plot", nil, func() {
+//         implot.SetupAxes("x", "y", 0, 0)
+//         implot.WithStyleVar(implot.StyleVar_LineWeight, float32(2), func() {
+//             implot.PlotLine("line", data)
+//         })
+//     })
+
+// Plot calls #f between BeginPlot(title, opts...) and EndPlot().
+// #f is only called if BeginPlot returns true, which is returned.
+// #opts are the options of BeginPlot, and can be nil.
+func Plot(title string, opts []Option, f func()) bool {
+	if !BeginPlot(title, opts...) {
+		return false
+	}
+	defer EndPlot()
+	f()
+	return true
+}
+
+// PlotV calls #f between BeginPlotV(title, size, flags) and EndPlot().
+// #f is only called if BeginPlotV returns true, which is returned.
+func PlotV(title string, size imgui.Vec2, flags Flags, f func()) bool {
+	if !BeginPlotV(title, size, flags) {
+		return false
+	}
+	defer EndPlot()
+	f()
+	return true
+}
+
+// Subplots calls #f between BeginSubplots(title, rows, cols, opts...) and EndSubplots().
+// #f is only called if BeginSubplots returns true, which is returned.
+// #opts are the options of BeginSubplots, and can be nil.
+func Subplots(title string, rows, cols int, opts []Option, f func()) bool {
+	if !BeginSubplots(title, rows, cols, opts...) {
+		return false
+	}
+	defer EndSubplots()
+	f()
+	return true
+}
+
+// SubplotsV calls #f between BeginSubplotsV(...) and EndSubplots().
+// #f is only called if BeginSubplotsV returns true, which is returned.
+func SubplotsV(title string, rows, cols int, size imgui.Vec2, flags SubplotFlags, rowRatios, colRatios []float32, f func()) bool {
+	if !BeginSubplotsV(title, rows, cols, size, flags, rowRatios, colRatios) {
+		return false
+	}
+	defer EndSubplots()
+	f()
+	return true
+}
+
+// WithStyleVar calls #f with a style variable pushed, as PushStyleVar does.
+func WithStyleVar(v StyleVar, val interface{}, f func()) {
+	PushStyleVar(v, val)
+	defer PopStyleVar()
+	f()
+}
+
+// WithStyleColor calls #f with a style color pushed, as PushStyleColor does.
+func WithStyleColor(id StyleCol, color imgui.Vec4, f func()) {
+	PushStyleColor(id, color)
+	defer PopStyleColor()
+	f()
+}
+
+// WithColormap calls #f with a colormap pushed, as PushColormap does.
+func WithColormap(cmap Colormap, f func()) {
+	PushColormap(cmap)
+	defer PopColormap()
+	f()
+}
+
+// WithColormapName calls #f with a colormap pushed by name, as PushColormapName does.
+func WithColormapName(name string, f func()) {
+	PushColormapName(name)
+	defer PopColormap()
+	f()
+}
@@ -5,7 +5,6 @@ package implot
 import "C"
 import (
 	"fmt"
-	"reflect"
 	"unsafe"
 )
 
@@ -43,6 +42,7 @@ type Formatter func(val float64, userData interface{}) string
 
 // SetupAxis enables an axis or sets the label and/or flags for an existing axis.
 func SetupAxis(axis Axis, label string, flags AxisFlags) {
+	validateSetup("SetupAxis")
 	if len(label) == 0 {
 		C.igpSetupAxis(C.igpAxis(axis), nil, C.igpAxisFlags(flags))
 	} else {
@@ -55,48 +55,27 @@ func SetupAxis(axis Axis, label string, flags AxisFlags) {
 //
 // Note that SetupAxisLinks() is absent. I don't really know how to implement that.
 func SetupAxisLimits(axis Axis, vmin, vmax float64, cond Condition) {
+	validateSetup("SetupAxisLimits")
 	C.igpSetupAxisLimits(C.igpAxis(axis), C.double(vmin), C.double(vmax), C.igpCondition(cond))
 }
 
 // SetupAxisFormat sets the format of numeric axis labels via formater specifier (default="%g").
 // The formatted value will be C.double, and you can also use %f.
 func SetupAxisFormat(axis Axis, fmt string) {
+	validateSetup("SetupAxisFormat")
 	C.igpSetupAxisFormat(C.igpAxis(axis), wrapString(fmt))
 }
 
-// For use by SetupAxisFormatCallback and callbacks
-var (
-	// Callbacks. MUST be cleaned AFTER every EndPlot().
-	axisFormatCb map[uintptr]struct {
-		fmt      Formatter
-		userData interface{}
-	} = make(map[uintptr]struct {
-		fmt      Formatter
-		userData interface{}
-	})
-	// Callbacks after every EndPlot(), for cleaning.
-	axisEndPlotCb []func()
-)
-
-func addEndPlotCb(f func()) {
-	axisEndPlotCb = append(axisEndPlotCb, f)
-}
-
 //export igpgoAxisFormatCb
 func igpgoAxisFormatCb(value float64, buf *byte, size C.int, cbid uintptr) {
-	// Construct a ByteSlice for conveience
-	bhead := &reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(buf)),
-		Len:  int(size),
-		Cap:  int(size),
-	}
-	b := *((*[]byte)(unsafe.Pointer(bhead)))
+	b := unsafe.Slice(buf, int(size))
 
-	cb, ok := axisFormatCb[cbid]
+	cb, ok := current().formatters[cbid]
 	if !ok {
 		panic(fmt.Errorf("igpgoAxisFormatCb() called with invalid callback ID (%d)", cbid))
 	}
-	copy(b, cb.fmt(value, cb.userData)+"\x00")
+	// Truncate to fit the terminating NUL, without concatenating a new string
+	b[copy(b[:len(b)-1], cb.fmt(value, cb.userData))] = 0
 }
 
 // SetupAxisFormatCallback sets the format of numeric axis labels via formatter callback.
@@ -104,8 +83,10 @@ func igpgoAxisFormatCb(value float64, buf *byte, size C.int, cbid uintptr) {
 // The userData value will be discarded on every EndPlot, so hopefully this will not
 // cause a memory leak.
 func SetupAxisFormatCallback(axis Axis, formatter Formatter, userData interface{}) {
-	cbid := uintptr(len(axisFormatCb) + 1)
-	axisFormatCb[cbid] = struct {
+	validateSetup("SetupAxisFormatCallback")
+	s := current()
+	cbid := s.newHandle()
+	s.formatters[cbid] = struct {
 		fmt      Formatter
 		userData interface{}
 	}{fmt: formatter, userData: userData}
@@ -120,11 +101,8 @@ func SetupAxisFormatCallback(axis Axis, formatter Formatter, userData interface{
 //
 // Note that if len(values)!=len(labels), it takes len(values).
 func SetupAxisTickValues(axis Axis, values []float64, labels []string, keepDefaults bool) {
-	dsp, fin := wrapDoubleSliceAlloc(values)
-	addEndPlotCb(fin)
-	sp, fin := wrapStringSlice(labels)
-	addEndPlotCb(fin)
-	C.igpSetupAxisTickValues(C.igpAxis(axis), dsp, C.int(len(values)), sp, C.bool(keepDefaults))
+	validateSetup("SetupAxisTickValues")
+	C.igpSetupAxisTickValues(C.igpAxis(axis), wrapDoubleSlice(values), C.int(len(values)), wrapStringSlice(labels), C.bool(keepDefaults))
 }
 
 // SetupAxisTickRange set an axis' tick values (n of them from [vmin, vmax]) and labels.
@@ -132,14 +110,14 @@ func SetupAxisTickValues(axis Axis, values []float64, labels []string, keepDefau
 //
 // To keep the default ticks, set keep_default=true.
 func SetupAxisTickRange(axis Axis, vmin, vmax float64, n int, labels []string, keepDefaults bool) {
-	sp, fin := wrapStringSlice(labels)
-	addEndPlotCb(fin)
-	C.igpSetupAxisTickRange(C.igpAxis(axis), C.double(vmin), C.double(vmax), C.int(n), sp, C.bool(keepDefaults))
+	validateSetup("SetupAxisTickRange")
+	C.igpSetupAxisTickRange(C.igpAxis(axis), C.double(vmin), C.double(vmax), C.int(n), wrapStringSlice(labels), C.bool(keepDefaults))
 }
 
 // SetupAxes sets the label and/or flags for primary X and Y axes.
 // (shorthand for two calls to SetupAxis)
 func SetupAxes(xlabel, ylabel string, xflags, yflags AxisFlags) {
+	validateSetup("SetupAxes")
 	C.igpSetupAxes(wrapString(xlabel), wrapString(ylabel), C.igpAxisFlags(xflags), C.igpAxisFlags(yflags))
 }
 
@@ -147,11 +125,13 @@ func SetupAxes(xlabel, ylabel string, xflags, yflags AxisFlags) {
 // If ImPlotCond_Always is used, the axes limits will be locked.
 // (shorthand for two calls to SetupAxisLimits)
 func SetupAxesLimits(xmin, xmax, ymin, ymax float64, cond Condition) {
+	validateSetup("SetupAxesLimits")
 	C.igpSetupAxesLimits(C.double(xmin), C.double(xmax), C.double(ymin), C.double(ymax), C.igpCondition(cond))
 }
 
 // SetupLegend sets up the position and flags of the plot legend.
 func SetupLegend(location Location, flags LegendFlags) {
+	validateSetup("SetupLegend")
 	C.igpSetupLegend(C.igpLocation(location), C.igpLegendFlags(flags))
 }
 
@@ -159,6 +139,7 @@ func SetupLegend(location Location, flags LegendFlags) {
 // (the tiny xxx,yyy numbers on the plot).
 // The default is South|East (so bottom-right).
 func SetupMouseText(location Location, flags MouseTextFlags) {
+	validateSetup("SetupMouseText")
 	C.igpSetupMouseText(C.igpLocation(location), C.igpMouseTextFlags(flags))
 }
 
@@ -168,5 +149,6 @@ func SetupMouseText(location Location, flags MouseTextFlags) {
 // Note that calling this function is OPTIONAL; it will be called
 // by the first subsequent setup-locking API call.
 func SetupFinish() {
+	validateLock("SetupFinish")
 	C.igpSetupFinish()
 }
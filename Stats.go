@@ -0,0 +1,378 @@
+package implot
+
+// #include "wrapper/Main.h"
+// #include "wrapper/Plot.h"
+import "C"
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/inkyblackness/imgui-go/v4"
+)
+
+// The statistical overlays compute a derived series from X/Y coords and plot
+// it with the usual items. The results are cached per Context, as long as
+// the slices passed are the same (same array and length) with the same
+// parameters, so modifying the data in place needs a new slice to be noticed.
+// The derived series are plotted with the scales set by SetupAxisScale applied.
+
+// MovingAverageKind selects the average of PlotMovingAverage.
+type MovingAverageKind int
+
+const (
+	MovingAverage_SMA MovingAverageKind = iota // simple moving average of the last #window values
+	MovingAverage_EMA                          // exponential moving average, with alpha = 2/(window+1)
+)
+
+// Kinds of the cached results
+const (
+	statsSMA = iota
+	statsEMA
+	statsBands
+	statsRegression
+	statsSorted // see Distribution.go
+	statsViolin
+)
+
+// statsCacheSize is the number of results kept in the cache of a Context.
+// It holds more while a single frame uses more.
+const statsCacheSize = 64
+
+// statsKey identifies a cached result.
+type statsKey struct {
+	kind   int
+	xs, ys unsafe.Pointer
+	n      int
+	window int
+	param  float64
+}
+
+// statsEntry is a cached result: the X coords and one or two Y series.
+type statsEntry struct {
+	xs, y1, y2 []float64
+	text       string
+	used       uint64
+	frame      int // the last ImGui frame it was used in
+}
+
+// statsCache returns the cached result of #kind for the data and parameters,
+// computing it with #compute on a miss. The least recently used result is dropped when the cache is full,
+// unless it was used in the current frame: every result of a frame is kept, so that plots with many groups
+// are not recomputed every frame.
+func statsCache[T Number](kind int, xs, ys []T, window int, param float64, compute func(e *statsEntry)) *statsEntry {
+	s := current()
+	key := statsKey{kind: kind, n: len(ys), window: window, param: param}
+	if len(xs) > 0 && len(ys) > 0 {
+		key.xs, key.ys = unsafe.Pointer(&xs[0]), unsafe.Pointer(&ys[0])
+	}
+
+	s.statsClock++
+	frame := int(C.igpGetFrameCount())
+	if e, ok := s.stats[key]; ok {
+		e.used, e.frame = s.statsClock, frame
+		return e
+	}
+	if s.stats == nil {
+		s.stats = make(map[statsKey]*statsEntry)
+	}
+	if len(s.stats) >= statsCacheSize {
+		var oldest statsKey
+		min := uint64(math.MaxUint64)
+		for k, e := range s.stats {
+			if e.used < min {
+				oldest, min = k, e.used
+			}
+		}
+		if s.stats[oldest].frame != frame {
+			delete(s.stats, oldest)
+		}
+	}
+
+	e := &statsEntry{used: s.statsClock, frame: frame}
+	compute(e)
+	s.stats[key] = e
+	return e
+}
+
+// PlotMovingAverage plots a line of the moving average of #ys over #window values.
+//
+// It returns an error if len(xs) != len(ys).
+func PlotMovingAverage[T Number](label string, xs, ys []T, window int, kind MovingAverageKind) error {
+	if err := checkLengths("PlotMovingAverage", len(xs), len(ys)); err != nil {
+		return err
+	}
+	validateLock("PlotMovingAverage")
+	if window < 1 {
+		window = 1
+	}
+
+	var e *statsEntry
+	if kind == MovingAverage_EMA {
+		e = statsCache(statsEMA, xs, ys, window, 0, func(e *statsEntry) {
+			alpha := 2 / (float64(window) + 1)
+			e.xs, e.y1 = make([]float64, len(ys)), make([]float64, len(ys))
+			for i := range ys {
+				e.xs[i], e.y1[i] = float64(xs[i]), float64(ys[i])
+				if i > 0 {
+					e.y1[i] = alpha*e.y1[i] + (1-alpha)*e.y1[i-1]
+				}
+			}
+		})
+	} else {
+		e = statsCache(statsSMA, xs, ys, window, 0, func(e *statsEntry) {
+			e.xs, e.y1, _ = rollingMeanStd(xs, ys, window, false)
+		})
+	}
+
+	typ, xp, yp, count, stride := wrapXYSliceScaled(e.xs, e.y1)
+	C.igpPlotLineXY(typ, wrapString(label), xp, yp, count, 0, stride)
+	return nil
+}
+
+// PlotBands plots Bollinger bands: a shaded region between the moving
+// average of #ys over #window values, plus and minus #k times their
+// standard deviation.
+//
+// It returns an error if len(xs) != len(ys).
+func PlotBands[T Number](label string, xs, ys []T, window int, k float64) error {
+	if err := checkLengths("PlotBands", len(xs), len(ys)); err != nil {
+		return err
+	}
+	validateLock("PlotBands")
+	if window < 1 {
+		window = 1
+	}
+
+	e := statsCache(statsBands, xs, ys, window, k, func(e *statsEntry) {
+		var mean, std []float64
+		e.xs, mean, std = rollingMeanStd(xs, ys, window, true)
+		e.y1, e.y2 = make([]float64, len(mean)), make([]float64, len(mean))
+		for i := range mean {
+			e.y1[i], e.y2[i] = mean[i]-k*std[i], mean[i]+k*std[i]
+		}
+	})
+
+	PlotShadedLinesXY(label, e.xs, e.y1, e.y2)
+	return nil
+}
+
+// rollingMeanStd returns the mean and the (population) standard deviation
+// of every #window consecutive values of #ys, at the X of the last of them.
+func rollingMeanStd[T Number](xs, ys []T, window int, withStd bool) (outX, mean, std []float64) {
+	if len(ys) < window {
+		return nil, nil, nil
+	}
+	n := len(ys) - window + 1
+	outX, mean = make([]float64, n), make([]float64, n)
+	if withStd {
+		std = make([]float64, n)
+	}
+
+	// Sums of the values shifted by the first one, to limit cancellation in the variance
+	shift := float64(ys[0])
+	var sum, sum2 float64
+	for i := range ys {
+		v := float64(ys[i]) - shift
+		sum, sum2 = sum+v, sum2+v*v
+		if i >= window {
+			old := float64(ys[i-window]) - shift
+			sum, sum2 = sum-old, sum2-old*old
+		}
+		if j := i - window + 1; j >= 0 {
+			m := sum / float64(window)
+			outX[j], mean[j] = float64(xs[i]), m+shift
+			if withStd {
+				std[j] = math.Sqrt(math.Max(0, sum2/float64(window)-m*m))
+			}
+		}
+	}
+	return
+}
+
+// ShowEquation makes PlotRegression annotate the fitted line with its equation.
+func ShowEquation() Option {
+	return func(o *options) { o.showEquation = true }
+}
+
+// regressionPoints is the number of points a nonlinear fit is plotted with.
+const regressionPoints = 128
+
+// PlotRegression plots the least-squares polynomial fit of #degree
+// (1 for a linear regression) of #xs and #ys, over the range of #xs.
+// The optional parameter ShowEquation applies.
+//
+// The degree is lowered to len(xs)-1 if it is higher.
+//
+// It returns an error if len(xs) != len(ys).
+func PlotRegression[T Number](label string, xs, ys []T, degree int, opts ...Option) error {
+	if err := checkLengths("PlotRegression", len(xs), len(ys)); err != nil {
+		return err
+	}
+	o := applyOptions(opts)
+	validateLock("PlotRegression")
+	if degree >= len(xs) {
+		degree = len(xs) - 1
+	}
+	if degree < 0 {
+		degree = 0
+	}
+
+	// A line is plotted with 2 points, unless the plot has scales which bend it
+	var bent float64
+	if current().scaled {
+		bent = 1
+	}
+	e := statsCache(statsRegression, xs, ys, degree, bent, func(e *statsEntry) {
+		if len(xs) == 0 {
+			return
+		}
+		coef, center, scale := polyfit(xs, ys, degree)
+
+		xmin, xmax := float64(xs[0]), float64(xs[0])
+		for _, x := range xs {
+			xmin, xmax = math.Min(xmin, float64(x)), math.Max(xmax, float64(x))
+		}
+		n := regressionPoints
+		if degree <= 1 && bent == 0 {
+			n = 2
+		}
+		e.xs, e.y1 = make([]float64, n), make([]float64, n)
+		for i := range e.xs {
+			x := xmin + (xmax-xmin)*float64(i)/float64(n-1)
+			e.xs[i], e.y1[i] = x, polyval(coef, (x-center)/scale)
+		}
+		e.text = equation(coef, center, scale)
+	})
+
+	typ, xp, yp, count, stride := wrapXYSliceScaled(e.xs, e.y1)
+	C.igpPlotLineXY(typ, wrapString(label), xp, yp, count, 0, stride)
+	if o.showEquation && len(e.xs) > 0 {
+		last := len(e.xs) - 1
+		x, y := ScaleValue(Axis_X1, e.xs[last]), ScaleValue(Axis_Y1, e.y1[last])
+		Annotation(x, y, GetLastItemColor(), imgui.Vec2{X: -10, Y: -10}, true, e.text)
+	}
+	return nil
+}
+
+// polyfit returns the coefficients (constant first) of the least-squares
+// polynomial of #degree in u = (x-center)/scale, which keeps the normal
+// equations well-conditioned.
+func polyfit[T Number](xs, ys []T, degree int) (coef []float64, center, scale float64) {
+	xmin, xmax := float64(xs[0]), float64(xs[0])
+	for _, x := range xs {
+		xmin, xmax = math.Min(xmin, float64(x)), math.Max(xmax, float64(x))
+	}
+	center, scale = (xmin+xmax)/2, (xmax-xmin)/2
+	if scale == 0 {
+		scale = 1
+	}
+
+	// Normal equations A*coef = b, with A[i][j] = sum(u^(i+j)) and b[i] = sum(y*u^i)
+	m := degree + 1
+	a := make([]float64, m*(m+1))
+	pow := make([]float64, 2*m)
+	for k := range xs {
+		u, y := (float64(xs[k])-center)/scale, float64(ys[k])
+		pow[0] = 1
+		for i := 1; i < len(pow); i++ {
+			pow[i] = pow[i-1] * u
+		}
+		for i := 0; i < m; i++ {
+			for j := 0; j < m; j++ {
+				a[i*(m+1)+j] += pow[i+j]
+			}
+			a[i*(m+1)+m] += y * pow[i]
+		}
+	}
+
+	// Gaussian elimination with partial pivoting
+	for col := 0; col < m; col++ {
+		pivot := col
+		for r := col + 1; r < m; r++ {
+			if math.Abs(a[r*(m+1)+col]) > math.Abs(a[pivot*(m+1)+col]) {
+				pivot = r
+			}
+		}
+		for c := 0; c <= m; c++ {
+			a[col*(m+1)+c], a[pivot*(m+1)+c] = a[pivot*(m+1)+c], a[col*(m+1)+c]
+		}
+		if a[col*(m+1)+col] == 0 {
+			continue
+		}
+		for r := col + 1; r < m; r++ {
+			f := a[r*(m+1)+col] / a[col*(m+1)+col]
+			for c := col; c <= m; c++ {
+				a[r*(m+1)+c] -= f * a[col*(m+1)+c]
+			}
+		}
+	}
+	coef = make([]float64, m)
+	for r := m - 1; r >= 0; r-- {
+		v := a[r*(m+1)+m]
+		for c := r + 1; c < m; c++ {
+			v -= a[r*(m+1)+c] * coef[c]
+		}
+		if d := a[r*(m+1)+r]; d != 0 {
+			coef[r] = v / d
+		}
+	}
+	return
+}
+
+// polyval evaluates a polynomial, constant coefficient first.
+func polyval(coef []float64, u float64) float64 {
+	var v float64
+	for i := len(coef) - 1; i >= 0; i-- {
+		v = v*u + coef[i]
+	}
+	return v
+}
+
+// equation formats the polynomial of polyfit in x, e.g. "y = 1.5x^2 - 3x + 0.25".
+func equation(coef []float64, center, scale float64) string {
+	// Expand sum(c[i] * ((x-center)/scale)^i) into powers of x
+	m := len(coef)
+	xcoef := make([]float64, m)
+	binom := make([]float64, m) // row i of Pascal's triangle
+	for i := 0; i < m; i++ {
+		for j := i; j > 0; j-- {
+			binom[j] += binom[j-1]
+		}
+		binom[0] = 1
+		f := coef[i] / math.Pow(scale, float64(i))
+		for j := 0; j <= i; j++ {
+			xcoef[j] += f * binom[j] * math.Pow(-center, float64(i-j))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("y =")
+	first := true
+	for i := m - 1; i >= 0; i-- {
+		c := xcoef[i]
+		if c == 0 && !(first && i == 0) {
+			continue
+		}
+		switch {
+		case first && c < 0:
+			b.WriteString(" -")
+		case !first && c < 0:
+			b.WriteString(" - ")
+		case !first:
+			b.WriteString(" + ")
+		default:
+			b.WriteString(" ")
+		}
+		first = false
+		b.WriteString(strconv.FormatFloat(math.Abs(c), 'g', 4, 64))
+		if i >= 1 {
+			b.WriteByte('x')
+		}
+		if i >= 2 {
+			b.WriteString("^" + strconv.Itoa(i))
+		}
+	}
+	return b.String()
+}
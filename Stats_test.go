@@ -0,0 +1,75 @@
+package implot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPolyfit(t *testing.T) {
+	tests := []struct {
+		name     string
+		xs       []float64
+		poly     func(x float64) float64
+		degree   int
+		equation string
+	}{
+		{"constant", []float64{0, 1, 2, 3}, func(x float64) float64 { return 4 }, 0, "y = 4"},
+		{"line", []float64{0, 1, 2, 3}, func(x float64) float64 { return 2*x - 1 }, 1, "y = 2x - 1"},
+		{"parabola", []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, func(x float64) float64 { return 1.5*x*x - 3*x + 0.25 }, 2, "y = 1.5x^2 - 3x + 0.25"},
+		{"negative leading", []float64{-4, -1, 0, 2, 5}, func(x float64) float64 { return -x*x + 7 }, 2, "y = -1x^2 + 7"},
+		{"far from 0", []float64{1000, 1001, 1002, 1003}, func(x float64) float64 { return 0.5*x + 3 }, 1, "y = 0.5x + 3"},
+		{"single x", []float64{3, 3, 3}, func(x float64) float64 { return 6 }, 0, "y = 6"},
+	}
+	for _, tt := range tests {
+		ys := make([]float64, len(tt.xs))
+		for i, x := range tt.xs {
+			ys[i] = tt.poly(x)
+		}
+		coef, center, scale := polyfit(tt.xs, ys, tt.degree)
+		for i, x := range tt.xs {
+			if got := polyval(coef, (x-center)/scale); math.Abs(got-ys[i]) > 1e-9*math.Max(1, math.Abs(ys[i])) {
+				t.Errorf("%s: fit(%g) = %g, want %g", tt.name, x, got, ys[i])
+			}
+		}
+		if got := equation(coef, center, scale); got != tt.equation {
+			t.Errorf("%s: equation = %q, want %q", tt.name, got, tt.equation)
+		}
+	}
+}
+
+func TestRollingMeanStd(t *testing.T) {
+	tests := []struct {
+		name      string
+		ys        []float64
+		window    int
+		mean, std []float64
+	}{
+		{"ramp", []float64{1, 2, 3, 4, 5}, 3, []float64{2, 3, 4}, []float64{math.Sqrt(2.0 / 3), math.Sqrt(2.0 / 3), math.Sqrt(2.0 / 3)}},
+		{"window 1", []float64{5, -1}, 1, []float64{5, -1}, []float64{0, 0}},
+		{"constant", []float64{7, 7, 7, 7}, 2, []float64{7, 7, 7}, []float64{0, 0, 0}},
+		{"large offset", []float64{1e9 + 1, 1e9 + 2, 1e9 + 3, 1e9 + 1}, 3, []float64{1e9 + 2, 1e9 + 2}, []float64{math.Sqrt(2.0 / 3), math.Sqrt(2.0 / 3)}},
+		{"short", []float64{1, 2}, 3, nil, nil},
+	}
+	for _, tt := range tests {
+		xs := make([]float64, len(tt.ys))
+		for i := range xs {
+			xs[i] = float64(10 * i)
+		}
+		outX, mean, std := rollingMeanStd(xs, tt.ys, tt.window, true)
+		if len(mean) != len(tt.mean) || len(std) != len(tt.std) || len(outX) != len(tt.mean) {
+			t.Errorf("%s: got %d means and %d deviations, want %d", tt.name, len(mean), len(std), len(tt.mean))
+			continue
+		}
+		for i := range mean {
+			if want := xs[i+tt.window-1]; outX[i] != want {
+				t.Errorf("%s: x[%d] = %g, want %g", tt.name, i, outX[i], want)
+			}
+			if math.Abs(mean[i]-tt.mean[i]) > 1e-9 {
+				t.Errorf("%s: mean[%d] = %g, want %g", tt.name, i, mean[i], tt.mean[i])
+			}
+			if math.Abs(std[i]-tt.std[i]) > 1e-6 {
+				t.Errorf("%s: std[%d] = %g, want %g", tt.name, i, std[i], tt.std[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package implot
+
+import (
+	"math"
+	"sync"
+)
+
+// Stream is a series of Points that is safe for concurrent use.
+//
+// Producers can Append from any goroutine, while the render thread plots
+// a consistent snapshot of it with PlotXXXStream. It keeps at most a fixed
+// number of points, and optionally only the points within a time window
+// (in X units) of the newest one.
+//
+// The snapshot buffer is allocated once with the Stream, so plotting it
+// does not allocate. All the PlotXXXStream calls on a Stream must be made
+// from the same goroutine, which is usually the one rendering ImGui.
+type Stream struct {
+	lock   sync.Mutex
+	data   []Point // fixed-size circular storage
+	start  int     // index of the oldest point
+	n      int     // number of points stored
+	window float64 // points older than newest.X-window are evicted
+
+	snap []Point // snapshot buffer for the render thread
+}
+
+// NewStream creates a Stream holding at most #capacity points.
+//
+// If #window is positive, points with X less than (X of the newest point - #window)
+// are evicted on Append. Set it to 0 or +Inf to disable time-window eviction.
+func NewStream(capacity int, window float64) *Stream {
+	if capacity <= 0 {
+		panic("NewStream called with a non-positive capacity")
+	}
+	if window <= 0 {
+		window = math.Inf(1)
+	}
+	return &Stream{
+		data:   make([]Point, capacity),
+		window: window,
+		snap:   make([]Point, 0, capacity),
+	}
+}
+
+// Append adds a point to the stream, evicting the oldest one if it is full.
+//
+// Points are expected to be appended in increasing X order.
+func (s *Stream) Append(x, y float64) {
+	s.lock.Lock()
+	s.push(Point{X: x, Y: y})
+	s.evict(x)
+	s.lock.Unlock()
+}
+
+// AppendPoints adds a batch of points under a single lock.
+func (s *Stream) AppendPoints(ps ...Point) {
+	if len(ps) == 0 {
+		return
+	}
+	s.lock.Lock()
+	for _, p := range ps {
+		s.push(p)
+	}
+	s.evict(ps[len(ps)-1].X)
+	s.lock.Unlock()
+}
+
+// push adds a point. s.lock must be held.
+func (s *Stream) push(p Point) {
+	c := len(s.data)
+	if s.n < c {
+		s.data[(s.start+s.n)%c] = p
+		s.n++
+	} else {
+		s.data[s.start] = p
+		s.start = (s.start + 1) % c
+	}
+}
+
+// evict drops the points out of the time window ending at newest. s.lock must be held.
+func (s *Stream) evict(newest float64) {
+	cutoff := newest - s.window
+	for s.n > 0 && s.data[s.start].X < cutoff {
+		s.start = (s.start + 1) % len(s.data)
+		s.n--
+	}
+}
+
+// Len returns the number of points currently in the stream.
+func (s *Stream) Len() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.n
+}
+
+// Cap returns the maximum number of points in the stream.
+func (s *Stream) Cap() int {
+	return len(s.data)
+}
+
+// Clear removes all the points in the stream.
+func (s *Stream) Clear() {
+	s.lock.Lock()
+	s.start, s.n = 0, 0
+	s.lock.Unlock()
+}
+
+// Snapshot appends the points currently in the stream, oldest first, to dst
+// and returns the result.
+func (s *Stream) Snapshot(dst []Point) []Point {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	end := s.start + s.n
+	if end <= len(s.data) {
+		return append(dst, s.data[s.start:end]...)
+	}
+	dst = append(dst, s.data[s.start:]...)
+	return append(dst, s.data[:end-len(s.data)]...)
+}
+
+// snapshot refreshes the internal snapshot buffer and returns it.
+func (s *Stream) snapshot() []Point {
+	s.snap = s.Snapshot(s.snap[:0])
+	return s.snap
+}
+
+// PlotLineStream plots a standard 2D line plot from a snapshot of a Stream.
+func PlotLineStream(label string, s *Stream) {
+	PlotLineP(label, s.snapshot())
+}
+
+// PlotScatterStream plots a standard 2D scatter plot from a snapshot of a Stream.
+//
+// Default marker is ImPlotMarker_Circle.
+func PlotScatterStream(label string, s *Stream) {
+	PlotScatterP(label, s.snapshot())
+}
+
+// PlotStairsStream plots a stairstep graph from a snapshot of a Stream.
+//
+// The y value is continued constantly from every x position,
+// i.e. the interval [x[i], x[i+1]) has the value y[i].
+func PlotStairsStream(label string, s *Stream) {
+	PlotStairsP(label, s.snapshot())
+}
+
+// PlotShadedRefStream plots a shaded (filled) region between a line and a horizontal reference,
+// from a snapshot of a Stream.
+//
+// Set yref to +/-INFINITY for infinite fill extents.
+func PlotShadedRefStream(label string, s *Stream, yref float64) {
+	PlotShadedRefP(label, s.snapshot(), yref)
+}
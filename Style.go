@@ -198,17 +198,20 @@ func StyleColorsLight() {
 // You MUST call a pop for every push, otherwise you will leak memory!
 // This behaves just like ImGui itself.
 func PushStyleColor(id StyleCol, color imgui.Vec4) {
+	validatePush(stackStyleColor)
 	C.igpPushStyleColor(C.igpStyleCol(id), wrapVec4(color))
 }
 
 // PopStyleColor pops one color off the style stack.
 // It calls PopStyleColorV(1).
 func PopStyleColor() {
+	validatePop("PopStyleColor", stackStyleColor, 1)
 	C.igpPopStyleColor(1)
 }
 
 // PopStyleColorV pops #count colors off the stack.
 func PopStyleColorV(count int) {
+	validatePop("PopStyleColorV", stackStyleColor, count)
 	C.igpPopStyleColor(C.int(count))
 }
 
@@ -273,6 +276,7 @@ func PushStyleVar(v StyleVar, val interface{}) {
 // You MUST call a pop for every push, otherwise you will leak memory!
 // This behaves just like ImGui itself.
 func PushStyleVarFloat(v StyleVar, val float32) {
+	validatePush(stackStyleVar)
 	C.igpPushStyleVarFloat(C.igpStyleVar(v), C.float(val))
 }
 
@@ -283,6 +287,7 @@ func PushStyleVarFloat(v StyleVar, val float32) {
 // You MUST call a pop for every push, otherwise you will leak memory!
 // This behaves just like ImGui itself.
 func PushStyleVarVec2(v StyleVar, val imgui.Vec2) {
+	validatePush(stackStyleVar)
 	C.igpPushStyleVarVec2(C.igpStyleVar(v), wrapVec2(val))
 }
 
@@ -293,17 +298,20 @@ func PushStyleVarVec2(v StyleVar, val imgui.Vec2) {
 // You MUST call a pop for every push, otherwise you will leak memory!
 // This behaves just like ImGui itself.
 func PushStyleVarInt(v StyleVar, val int) {
+	validatePush(stackStyleVar)
 	C.igpPushStyleVarInt(C.igpStyleVar(v), C.int(val))
 }
 
 // PopStyleVar pops one variable off the stack.
 // It calls PopStyleVarV(1).
 func PopStyleVar() {
+	validatePop("PopStyleVar", stackStyleVar, 1)
 	C.igpPopStyleVar(1)
 }
 
 // PopStyleVarV pops #count variables off the stack.
 func PopStyleVarV(count int) {
+	validatePop("PopStyleVarV", stackStyleVar, count)
 	C.igpPopStyleVar(C.int(count))
 }
 
@@ -0,0 +1,83 @@
+package implot
+
+// #include <stdlib.h>
+// #include "wrapper/Time.h"
+import "C"
+import (
+	"time"
+	"unsafe"
+)
+
+// DatePickerLevel is the level of detail shown by ShowDatePicker.
+type DatePickerLevel C.int
+
+// Date picker levels
+const (
+	DatePickerLevel_Day   DatePickerLevel = iota // a calendar of days in a month
+	DatePickerLevel_Month                        // a grid of months in a year
+	DatePickerLevel_Year                         // a grid of years
+)
+
+// wrapTime converts a time.Time to ImPlotTime, keeping microsecond precision.
+func wrapTime(t time.Time) C.igpTime {
+	return C.igpTime{s: C.longlong(t.Unix()), us: C.int(t.Nanosecond() / 1000)}
+}
+
+// unwrapTime converts a ImPlotTime back to time.Time in the given location.
+func unwrapTime(t C.igpTime, loc *time.Location) time.Time {
+	return time.Unix(int64(t.s), int64(t.us)*1000).In(loc)
+}
+
+// ShowDatePicker shows a date picker widget block (year/month/day).
+//
+// #level is the picker level, which is modified by user interaction.
+// Keep it around across frames, starting with DatePickerLevel_Day.
+//
+// #t will be set when a day is clicked and the function will return true.
+// The time-of-day part of #t is kept, and the location of #t is preserved.
+//
+// #t1 and #t2 are optional dates to highlight; set them to nil to disable.
+//
+// Whether the dates are shown in UTC or local time is decided by
+// ImPlotStyle.UseLocalTime, just like the Time axes.
+func ShowDatePicker(id string, level *DatePickerLevel, t *time.Time, t1, t2 *time.Time) bool {
+	cid := C.CString(id)
+	defer C.free(unsafe.Pointer(cid))
+
+	var ct1, ct2 C.igpTime
+	var pt1, pt2 *C.igpTime
+	if t1 != nil {
+		ct1 = wrapTime(*t1)
+		pt1 = &ct1
+	}
+	if t2 != nil {
+		ct2 = wrapTime(*t2)
+		pt2 = &ct2
+	}
+
+	clevel := C.int(*level)
+	ct := wrapTime(*t)
+	ok := bool(C.igpShowDatePicker(cid, &clevel, &ct, pt1, pt2))
+	*level = DatePickerLevel(clevel)
+	if ok {
+		*t = unwrapTime(ct, t.Location())
+	}
+	return ok
+}
+
+// ShowTimePicker shows a time picker widget block (hour/min/sec).
+//
+// #t will be set when a new hour, minute, or sec is selected or am/pm
+// is toggled, and the function will return true.
+// The location of #t is preserved.
+func ShowTimePicker(id string, t *time.Time) bool {
+	cid := C.CString(id)
+	defer C.free(unsafe.Pointer(cid))
+
+	ct := wrapTime(*t)
+	ok := bool(C.igpShowTimePicker(cid, &ct))
+	if ok {
+		*t = unwrapTime(ct, t.Location())
+	}
+	return ok
+}
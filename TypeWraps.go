@@ -1,11 +1,8 @@
 package implot
 
-// #include <stdlib.h>
-// #include <string.h>
 // #include "wrapper/Types.h"
 import "C"
 import (
-	"reflect"
 	"unsafe"
 
 	"github.com/inkyblackness/imgui-go/v4"
@@ -31,77 +28,92 @@ func unwrapVec4(v C.igpVec4) imgui.Vec4 {
 	return imgui.Vec4{X: float32(v.x), Y: float32(v.y), Z: float32(v.z), W: float32(v.w)}
 }
 
+// wrapString copies a string into the string arena of the current context.
+// It is valid until the next BeginPlot or EndPlot.
 func wrapString(str string) *C.char {
-	buf := make([]byte, len(str)+1)
-	copy(buf, str)
-	return (*C.char)(unsafe.Pointer(&buf[0]))
+	return current().arena.string(str)
 }
 
-func wrapDoubleSlice(slice []float64) *C.double {
-	return (*C.double)(unsafe.Pointer(&slice[0]))
+// dataTypeOf returns the igpDataType matching T.
+func dataTypeOf[T Number]() C.igpDataType {
+	var zero T
+	switch any(zero).(type) {
+	case float32:
+		return C.igpDataType_Float
+	case float64:
+		return C.igpDataType_Double
+	case int8:
+		return C.igpDataType_S8
+	case uint8:
+		return C.igpDataType_U8
+	case int16:
+		return C.igpDataType_S16
+	case uint16:
+		return C.igpDataType_U16
+	case int32:
+		return C.igpDataType_S32
+	case uint32:
+		return C.igpDataType_U32
+	case int64:
+		return C.igpDataType_S64
+	case uint64:
+		return C.igpDataType_U64
+	case int:
+		if unsafe.Sizeof(zero) == 8 {
+			return C.igpDataType_S64
+		}
+		return C.igpDataType_S32
+	case uint:
+		if unsafe.Sizeof(zero) == 8 {
+			return C.igpDataType_U64
+		}
+		return C.igpDataType_U32
+	}
+	panic("unreachable")
+}
+
+// wrapNumberSlice returns the data pointer, count and stride of a slice
+// of any Number, without copying. ptr is nil if the slice is empty.
+func wrapNumberSlice[T Number](slice []T) (typ C.igpDataType, ptr unsafe.Pointer, count, stride C.int) {
+	typ = dataTypeOf[T]()
+	stride = C.int(unsafe.Sizeof(*new(T)))
+	if len(slice) == 0 {
+		return
+	}
+	return typ, unsafe.Pointer(&slice[0]), C.int(len(slice)), stride
 }
 
-func wrapPointSlice(slice []Point) (xp, yp *C.double, count, stride C.int) {
+func wrapPointSlice(slice []Point) (xp, yp unsafe.Pointer, count, stride C.int) {
 	if len(slice) == 0 {
 		return
 	}
-	xp = (*C.double)(unsafe.Pointer(&slice[0].X))
-	yp = (*C.double)(unsafe.Pointer(&slice[0].Y))
+	xp = unsafe.Pointer(&slice[0].X)
+	yp = unsafe.Pointer(&slice[0].Y)
 	count = (C.int)(len(slice))
 	stride = (C.int)(unsafe.Sizeof(slice[0]))
 	return
 }
 
-func wrapXYSlice(xs, ys []float64) (xp, yp *C.double, count, stride C.int) {
+func wrapXYSlice[T Number](xs, ys []T) (typ C.igpDataType, xp, yp unsafe.Pointer, count, stride C.int) {
+	typ = dataTypeOf[T]()
+	stride = C.int(unsafe.Sizeof(*new(T)))
 	count = (C.int)(minint(len(xs), len(ys)))
 	if count == 0 {
 		return
 	}
-	xp = (*C.double)(unsafe.Pointer(&xs[0]))
-	yp = (*C.double)(unsafe.Pointer(&ys[0]))
-	stride = (C.int)(unsafe.Sizeof(xs[0]))
+	xp = unsafe.Pointer(&xs[0])
+	yp = unsafe.Pointer(&ys[0])
 	return
 }
 
-// wraps a []float64 allocating C memory for it.
-func wrapDoubleSliceAlloc(slice []float64) (sp *C.double, fin func()) {
-	n := len(slice)
-	if n == 0 {
-		return nil, func() {}
-	}
-	nbytes := n * int(unsafe.Sizeof(C.double(0)))
-	sp = (*C.double)(C.malloc(C.size_t(nbytes)))
-	C.memcpy(unsafe.Pointer(sp), unsafe.Pointer(&slice[0]), C.size_t(nbytes))
-	return sp, func() {
-		C.free(unsafe.Pointer(sp))
-	}
+// wrapDoubleSlice copies a []float64 into the string arena of the current context.
+// It is valid until the next BeginPlot or EndPlot.
+func wrapDoubleSlice(slice []float64) *C.double {
+	return current().arena.doubles(slice)
 }
 
-func wrapStringSlice(slice []string) (sp **C.char, fin func()) {
-	if len(slice) == 0 {
-		return nil, func() {}
-	}
-
-	n := len(slice)
-	rsp := make([]uintptr, n+1)
-	for i := 0; i < n; i++ {
-		nbytes := len(slice[i]) + 1
-		rs := C.malloc(C.size_t(nbytes))
-		//copy(C.GoBytes(rs, C.int(len(slice[i]))), slice[i])
-		bhead := &reflect.SliceHeader{
-			Data: uintptr(rs),
-			Len:  nbytes + 1,
-			Cap:  nbytes + 1,
-		}
-		b := *((*[]byte)(unsafe.Pointer(bhead)))
-		b[copy(b, slice[i])] = 0
-		rsp[i] = uintptr(rs)
-	}
-
-	// So this fin() keeps the rsp from getting GCed
-	return (**C.char)(unsafe.Pointer(&rsp[0])), func() {
-		for _, p := range rsp {
-			C.free(unsafe.Pointer(p))
-		}
-	}
+// wrapStringSlice copies a []string into the string arena of the current context.
+// It is valid until the next BeginPlot or EndPlot.
+func wrapStringSlice(slice []string) **C.char {
+	return current().arena.strings(slice)
 }
@@ -41,3 +41,17 @@ func (r Rect) Size() Point           { return Point{X: r.X.Size(), Y: r.Y.Size()
 func (r Rect) Clamp(p Point) Point   { return Point{X: r.X.Clamp(p.X), Y: r.Y.Clamp(p.Y)} }
 func (r Rect) Min() Point            { return Point{X: r.X.Min, Y: r.Y.Min} }
 func (r Rect) Max() Point            { return Point{X: r.X.Max, Y: r.Y.Max} }
+
+// Number is the set of scalar types that can be plotted directly.
+//
+// They are passed to ImPlot as-is with no conversion, matching the types
+// implot_items.cpp instantiates its templates for:
+// float, double, ImS8, ImU8, ImS16, ImU16, ImS32, ImU32, ImS64, ImU64.
+//
+// int and uint are plotted as either ImS32/ImU32 or ImS64/ImU64, depending
+// on their size on the platform.
+type Number interface {
+	float32 | float64 |
+		int8 | uint8 | int16 | uint16 | int32 | uint32 | int64 | uint64 |
+		int | uint
+}
@@ -0,0 +1,40 @@
+package implot
+
+import "fmt"
+
+// ImPlot has strict rules on the order of its API calls: Setup before plotting,
+// no Setup after setup is locked, Begin/End pairing, and Push/Pop balance.
+// Breaking them usually ends in an assertion deep in the C++ code.
+//
+// Building with the "implotdebug" build tag enables a validation layer, which
+// tracks the state of each Context on the Go side. A misuse then panics with
+// a *MisuseError naming the offending call, before ImPlot sees it:
+//
+//     go run -tags implotdebug .
+//
+// Without the tag, the checks compile to nothing.
+
+// MisuseError describes an API misuse caught by the validation layer.
+type MisuseError struct {
+	Call    string // the offending call, e.g. "SetupAxis"
+	Problem string // what is wrong with it
+}
+
+// Error returns the string representation.
+func (err *MisuseError) Error() string {
+	return fmt.Sprintf("implot: %s: %s", err.Call, err.Problem)
+}
+
+// Style stacks tracked by the validation layer
+const (
+	stackStyleVar = iota
+	stackStyleColor
+	stackColormap
+	stackCount
+)
+
+var stackNames = [stackCount]string{
+	stackStyleVar:   "style variables",
+	stackStyleColor: "style colors",
+	stackColormap:   "colormaps",
+}
@@ -0,0 +1,140 @@
+//go:build implotdebug
+
+package implot
+
+import "fmt"
+
+// debugState is the state of a Context tracked by the validation layer.
+type debugState struct {
+	inPlot        bool   // between a successful BeginPlot and EndPlot
+	setupLockedBy string // the call locking setup of the current plot; "" if unlocked
+
+	inSubplots   bool // between a successful BeginSubplots and EndSubplots
+	subplotsLeft int  // number of BeginPlot calls left in the current subplots
+
+	stacks [stackCount]int // depths of the style stacks
+}
+
+func misuse(call, format string, args ...interface{}) {
+	panic(&MisuseError{Call: call, Problem: fmt.Sprintf(format, args...)})
+}
+
+// validateBeginPlot checks a BeginPlot call, made before calling ImPlot.
+func validateBeginPlot(call string) {
+	d := &current().debug
+	if d.inPlot {
+		misuse(call, "called inside another plot (missing EndPlot?)")
+	}
+	if d.inSubplots {
+		if d.subplotsLeft <= 0 {
+			misuse(call, "called more than rows*cols times inside BeginSubplots")
+		}
+		d.subplotsLeft--
+	}
+}
+
+// validateBeganPlot records the result of BeginPlot.
+func validateBeganPlot(ok bool) {
+	d := &current().debug
+	d.inPlot = ok
+	d.setupLockedBy = ""
+}
+
+// validateEndPlot checks and records an EndPlot call.
+func validateEndPlot(call string) {
+	d := &current().debug
+	if !d.inPlot {
+		misuse(call, "called without a matching BeginPlot (or BeginPlot returned false)")
+	}
+	d.inPlot = false
+}
+
+// validateBeginSubplots checks a BeginSubplots call.
+func validateBeginSubplots(call string, rows, cols int) {
+	d := &current().debug
+	if d.inPlot {
+		misuse(call, "called inside a plot")
+	}
+	if d.inSubplots {
+		misuse(call, "called inside another subplots (missing EndSubplots?)")
+	}
+	if rows <= 0 || cols <= 0 {
+		misuse(call, "rows and cols must be greater than 0 (got %d, %d)", rows, cols)
+	}
+}
+
+// validateBeganSubplots records the result of BeginSubplots.
+func validateBeganSubplots(ok bool, rows, cols int) {
+	d := &current().debug
+	d.inSubplots = ok
+	d.subplotsLeft = rows * cols
+}
+
+// validateEndSubplots checks and records an EndSubplots call.
+func validateEndSubplots(call string) {
+	d := &current().debug
+	if d.inPlot {
+		misuse(call, "called inside a plot (missing EndPlot?)")
+	}
+	if !d.inSubplots {
+		misuse(call, "called without a matching BeginSubplots (or BeginSubplots returned false)")
+	}
+	d.inSubplots = false
+}
+
+// validateSetup checks a Setup call.
+func validateSetup(call string) {
+	d := &current().debug
+	if !d.inPlot {
+		misuse(call, "called outside of BeginPlot/EndPlot")
+	}
+	if d.setupLockedBy != "" {
+		misuse(call, "called after setup was locked by %s; make Setup calls right after BeginPlot", d.setupLockedBy)
+	}
+}
+
+// validateLock checks a call that needs a plot and locks setup, e.g. PlotXXX.
+func validateLock(call string) {
+	d := &current().debug
+	if !d.inPlot {
+		misuse(call, "called outside of BeginPlot/EndPlot")
+	}
+	if d.setupLockedBy == "" {
+		d.setupLockedBy = call
+	}
+}
+
+// validatePush records a push onto a style stack.
+func validatePush(stack int) {
+	current().debug.stacks[stack]++
+}
+
+// validatePop checks and records popping #count from a style stack.
+func validatePop(call string, stack, count int) {
+	d := &current().debug
+	if count > d.stacks[stack] {
+		misuse(call, "popping %d %s, but only %d are pushed", count, stackNames[stack], d.stacks[stack])
+	}
+	d.stacks[stack] -= count
+}
+
+// ValidateFrame checks that the current context is at a clean state, i.e., no
+// plot or subplots left open, and all the style stacks popped. Call it at the
+// end of every frame.
+//
+// It always returns nil if the validation layer is not enabled.
+func ValidateFrame() error {
+	d := &current().debug
+	switch {
+	case d.inPlot:
+		return &MisuseError{Call: "BeginPlot", Problem: "not ended with EndPlot by the end of the frame"}
+	case d.inSubplots:
+		return &MisuseError{Call: "BeginSubplots", Problem: "not ended with EndSubplots by the end of the frame"}
+	}
+	for i, n := range d.stacks {
+		if n != 0 {
+			return &MisuseError{Call: "Push", Problem: fmt.Sprintf("%d %s are not popped by the end of the frame", n, stackNames[i])}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+//go:build !implotdebug
+
+package implot
+
+// Without the implotdebug build tag, the validation layer does nothing.
+
+type debugState struct{}
+
+func validateBeginPlot(call string)                     {}
+func validateBeganPlot(ok bool)                         {}
+func validateEndPlot(call string)                       {}
+func validateBeginSubplots(call string, rows, cols int) {}
+func validateBeganSubplots(ok bool, rows, cols int)     {}
+func validateEndSubplots(call string)                   {}
+func validateSetup(call string)                         {}
+func validateLock(call string)                          {}
+func validatePush(stack int)                            {}
+func validatePop(call string, stack, count int)         {}
+
+// ValidateFrame checks that the current context is at a clean state, i.e., no
+// plot or subplots left open, and all the style stacks popped. Call it at the
+// end of every frame.
+//
+// It always returns nil if the validation layer is not enabled.
+func ValidateFrame() error { return nil }
@@ -1,5 +1,5 @@
 module github.com/Edgaru089/implot-go
 
-go 1.17
+go 1.18
 
 require github.com/inkyblackness/imgui-go/v4 v4.4.0
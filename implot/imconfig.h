@@ -19,6 +19,10 @@
 //#define IM_ASSERT(_EXPR)  MyAssert(_EXPR)
 //#define IM_ASSERT(_EXPR)  ((void)(_EXPR))     // Disable asserts
 
+// implot-go: failed asserts panic in Go with an implot.AssertionError, which can be recovered.
+#include "../wrapper/Panic.h"
+#define IM_ASSERT(_EXPR) ((_EXPR) ? (void)0 : igpPanicAssert(#_EXPR, __FILE__, __LINE__))
+
 //---- Define attributes of all API symbols declarations, e.g. for DLL under Windows
 // Using Dear ImGui via a shared library is not recommended, because of function call overhead and because we don't guarantee backward nor forward ABI compatibility.
 // DLL users: heaps and globals are not shared across DLL boundaries! You will need to call SetCurrentContext() + SetAllocatorFunctions()
@@ -0,0 +1,46 @@
+#include "Batch.h"
+#include "Plot.h"
+#include "Setup.h"
+#include "Style.h"
+
+
+// Every command calls the same wrapper function as the direct Go call,
+// so the results are the same.
+void igpReplay(const igpCmd *cmds, int n) {
+	for (int i = 0; i < n; i++) {
+		const igpCmd &c = cmds[i];
+		switch (c.op) {
+			case igpOp_SetupAxis: igpSetupAxis(c.i0, c.text, c.i1); break;
+			case igpOp_SetupAxisLimits: igpSetupAxisLimits(c.i0, c.d0, c.d1, c.i1); break;
+			case igpOp_SetupAxisFormat: igpSetupAxisFormat(c.i0, c.text); break;
+			case igpOp_SetupAxesLimits: igpSetupAxesLimits(c.d0, c.d1, c.d2, c.d3, c.i0); break;
+			case igpOp_SetupLegend: igpSetupLegend(c.i0, c.i1); break;
+			case igpOp_SetupFinish: igpSetupFinish(); break;
+
+			case igpOp_PushStyleColor: igpPushStyleColor(c.i0, c.c0); break;
+			case igpOp_PopStyleColor: igpPopStyleColor(c.i0); break;
+			case igpOp_PushStyleVarFloat: igpPushStyleVarFloat(c.i0, (float)c.d0); break;
+			case igpOp_PushStyleVarVec2: igpPushStyleVarVec2(c.i0, igpVec2{(float)c.d0, (float)c.d1}); break;
+			case igpOp_PushStyleVarInt: igpPushStyleVarInt(c.i0, c.i1); break;
+			case igpOp_PopStyleVar: igpPopStyleVar(c.i0); break;
+			case igpOp_SetNextLineStyle: igpSetNextLineStyle(c.c0, (float)c.d0); break;
+			case igpOp_SetNextFillStyle: igpSetNextFillStyle(c.c0, (float)c.d0); break;
+			case igpOp_SetNextMarkerStyle: igpSetNextMarkerStyle(c.i0, (float)c.d0, c.c0, (float)c.d1, c.c1); break;
+
+			case igpOp_PlotLine: igpPlotLine(c.type, c.text, c.ys, c.count, c.d0, c.d1, c.offset, c.stride); break;
+			case igpOp_PlotLineXY: igpPlotLineXY(c.type, c.text, c.xs, c.ys, c.count, c.offset, c.stride); break;
+			case igpOp_PlotScatter: igpPlotScatter(c.type, c.text, c.ys, c.count, c.d0, c.d1, c.offset, c.stride); break;
+			case igpOp_PlotScatterXY: igpPlotScatterXY(c.type, c.text, c.xs, c.ys, c.count, c.offset, c.stride); break;
+			case igpOp_PlotStairs: igpPlotStairs(c.type, c.text, c.ys, c.count, c.d0, c.d1, c.offset, c.stride); break;
+			case igpOp_PlotStairsXY: igpPlotStairsXY(c.type, c.text, c.xs, c.ys, c.count, c.offset, c.stride); break;
+			case igpOp_PlotShadedRef: igpPlotShadedRef(c.type, c.text, c.ys, c.count, c.d2, c.d0, c.d1, c.offset, c.stride); break;
+			case igpOp_PlotShadedRefXY: igpPlotShadedRefXY(c.type, c.text, c.xs, c.ys, c.count, c.d2, c.offset, c.stride); break;
+			case igpOp_PlotBars: igpPlotBars(c.type, c.text, c.ys, c.count, c.d2, c.d1, c.offset, c.stride); break;
+			case igpOp_PlotBarsXY: igpPlotBarsXY(c.type, c.text, c.xs, c.ys, c.count, c.d2, c.offset, c.stride); break;
+			case igpOp_PlotBarsH: igpPlotBarsH(c.type, c.text, c.ys, c.count, c.d2, c.d1, c.offset, c.stride); break;
+			case igpOp_PlotBarsHXY: igpPlotBarsHXY(c.type, c.text, c.xs, c.ys, c.count, c.d2, c.offset, c.stride); break;
+
+			default: break;
+		}
+	}
+}
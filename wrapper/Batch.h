@@ -0,0 +1,66 @@
+#pragma once
+
+#include "Types.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+
+// Opcodes of the commands recorded by implot.Batch [Batch.go]
+typedef enum {
+	igpOp_SetupAxis,
+	igpOp_SetupAxisLimits,
+	igpOp_SetupAxisFormat,
+	igpOp_SetupAxesLimits,
+	igpOp_SetupLegend,
+	igpOp_SetupFinish,
+
+	igpOp_PushStyleColor,
+	igpOp_PopStyleColor,
+	igpOp_PushStyleVarFloat,
+	igpOp_PushStyleVarVec2,
+	igpOp_PushStyleVarInt,
+	igpOp_PopStyleVar,
+	igpOp_SetNextLineStyle,
+	igpOp_SetNextFillStyle,
+	igpOp_SetNextMarkerStyle,
+
+	igpOp_PlotLine,
+	igpOp_PlotLineXY,
+	igpOp_PlotScatter,
+	igpOp_PlotScatterXY,
+	igpOp_PlotStairs,
+	igpOp_PlotStairsXY,
+	igpOp_PlotShadedRef,
+	igpOp_PlotShadedRefXY,
+	igpOp_PlotBars,
+	igpOp_PlotBarsXY,
+	igpOp_PlotBarsH,
+	igpOp_PlotBarsHXY,
+
+	igpOp_Count,
+} igpOp;
+
+// A recorded command. The meaning of the fields depends on #op,
+// see igpReplay() in Batch.cpp.
+typedef struct {
+	igpOp       op;
+	igpDataType type;
+
+	const char *text;
+	const void *xs, *ys;
+	int         count, offset, stride;
+
+	int     i0, i1;
+	double  d0, d1, d2, d3;
+	igpVec4 c0, c1;
+} igpCmd;
+
+// implot.Batch.Replay() [Batch.go]
+void igpReplay(const igpCmd *cmds, int n);
+
+
+#ifdef __cplusplus
+}
+#endif
@@ -0,0 +1,126 @@
+
+#include "Candlestick.h"
+#include <math.h>
+#include "ImPlot.hpp"
+#include "Wraps.hpp"
+#include "../implot/implot_internal.h"
+
+
+namespace {
+
+// Returns the index of the candle under #x, or -1.
+int candleAt(const double *xs, int count, double x, double half_width) {
+	int lo = 0, hi = count;
+	while (lo < hi) {
+		int mid = lo + (hi - lo) / 2;
+		if (xs[mid] < x - half_width)
+			lo = mid + 1;
+		else
+			hi = mid;
+	}
+	if (lo < count && xs[lo] <= x + half_width)
+		return lo;
+	return -1;
+}
+
+// Shows the tooltip of the candle under the mouse, with a highlight behind it.
+void candleTooltip(const char *label, const double *xs, const double *opens, const double *closes, const double *lows, const double *highs, const double *volumes, int count, double half_width) {
+	ImPlotPoint mouse = ImPlot::GetPlotMousePos();
+	int         idx   = candleAt(xs, count, mouse.x, half_width);
+	if (idx == -1)
+		return;
+
+	ImPlotPlot &plot = *GImPlot->CurrentPlot;
+	float       l    = ImPlot::PlotToPixels(xs[idx] - half_width, mouse.y).x;
+	float       r    = ImPlot::PlotToPixels(xs[idx] + half_width, mouse.y).x;
+	ImPlot::GetPlotDrawList()->AddRectFilled(ImVec2(l, plot.PlotRect.Min.y), ImVec2(r, plot.PlotRect.Max.y), IM_COL32(128, 128, 128, 64));
+
+	ImGui::BeginTooltip();
+	ImGui::TextUnformatted(label, ImGui::FindRenderedTextEnd(label));
+	if (plot.Axes[plot.CurrentX].IsTime()) {
+		char buf[32];
+		ImPlot::FormatDateTime(ImPlotTime::FromDouble(xs[idx]), buf, sizeof(buf), ImPlotDateTimeFmt(ImPlotDateFmt_DayMoYr, ImPlotTimeFmt_HrMin, ImPlot::GetStyle().Use24HourClock, ImPlot::GetStyle().UseISO8601));
+		ImGui::Text("Time:   %s", buf);
+	} else
+		ImGui::Text("X:      %g", xs[idx]);
+	ImGui::Text("Open:   %g", opens[idx]);
+	ImGui::Text("Close:  %g", closes[idx]);
+	ImGui::Text("Low:    %g", lows[idx]);
+	ImGui::Text("High:   %g", highs[idx]);
+	if (volumes)
+		ImGui::Text("Volume: %g", volumes[idx]);
+	ImGui::EndTooltip();
+}
+
+} // namespace
+
+
+void igpPlotCandlestick(const char *label, const double *xs, const double *opens, const double *closes, const double *lows, const double *highs, const double *volumes, int count, double width, igpVec4 bull_color, igpVec4 bear_color, float volume_height, bool tooltip) {
+	// The candles take up #width of the smallest spacing, so gaps (e.g. weekends) do not widen them.
+	// Without any (e.g. a single candle), they take up #width of a unit, or of a day on a Time axis.
+	double spacing = INFINITY;
+	for (int i = 1; i < count; i++)
+		if (xs[i] - xs[i - 1] > 0 && xs[i] - xs[i - 1] < spacing)
+			spacing = xs[i] - xs[i - 1];
+	if (spacing == INFINITY) {
+		ImPlotPlot *cur = GImPlot->CurrentPlot;
+		spacing = (cur && cur->Axes[cur->CurrentX].IsTime()) ? 86400 : 1;
+	}
+	double half_width = spacing * width / 2;
+
+	if (!ImPlot::BeginItem(label))
+		return;
+	ImPlotItem *item = ImPlot::GetCurrentItem();
+	ImU32       bull = ImGui::GetColorU32(unwrapVec4(bull_color));
+	ImU32       bear = ImGui::GetColorU32(unwrapVec4(bear_color));
+	item->Color      = bull;
+
+	if (ImPlot::FitThisFrame()) {
+		for (int i = 0; i < count; i++) {
+			ImPlot::FitPoint(ImPlotPoint(xs[i] - half_width, lows[i]));
+			ImPlot::FitPoint(ImPlotPoint(xs[i] + half_width, highs[i]));
+		}
+	}
+
+	ImPlotPlot &plot  = *GImPlot->CurrentPlot;
+	ImDrawList &draw  = *ImPlot::GetPlotDrawList();
+	ImPlotRange xview = plot.Axes[plot.CurrentX].Range;
+
+	// Volume bars, scaled to the largest visible volume
+	if (volumes && volume_height > 0) {
+		double vmax = 0;
+		for (int i = 0; i < count; i++)
+			if (xs[i] + half_width >= xview.Min && xs[i] - half_width <= xview.Max && volumes[i] > vmax)
+				vmax = volumes[i];
+		if (vmax > 0) {
+			float bottom = plot.PlotRect.Max.y, height = plot.PlotRect.GetHeight() * volume_height;
+			for (int i = 0; i < count; i++) {
+				if (xs[i] + half_width < xview.Min || xs[i] - half_width > xview.Max)
+					continue;
+				ImU32 col = ImAlphaU32(opens[i] > closes[i] ? bear : bull, 0.4f);
+				float l   = ImPlot::PlotToPixels(xs[i] - half_width, 0).x;
+				float r   = ImPlot::PlotToPixels(xs[i] + half_width, 0).x;
+				draw.AddRectFilled(ImVec2(l, bottom - (float)(volumes[i] / vmax) * height), ImVec2(r, bottom), col);
+			}
+		}
+	}
+
+	for (int i = 0; i < count; i++) {
+		if (xs[i] + half_width < xview.Min || xs[i] - half_width > xview.Max)
+			continue;
+		ImU32  col   = opens[i] > closes[i] ? bear : bull;
+		ImVec2 open  = ImPlot::PlotToPixels(xs[i] - half_width, opens[i]);
+		ImVec2 close = ImPlot::PlotToPixels(xs[i] + half_width, closes[i]);
+		ImVec2 low   = ImPlot::PlotToPixels(xs[i], lows[i]);
+		ImVec2 high  = ImPlot::PlotToPixels(xs[i], highs[i]);
+		draw.AddLine(low, high, col);
+		// Keep flat candles visible
+		if (ImAbs(close.y - open.y) < 1)
+			close.y = open.y + 1;
+		draw.AddRectFilled(ImMin(open, close), ImMax(open, close), col);
+	}
+	ImPlot::EndItem();
+
+	if (tooltip && ImPlot::IsPlotHovered())
+		candleTooltip(label, xs, opens, closes, lows, highs, volumes, count, half_width);
+}
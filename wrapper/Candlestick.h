@@ -0,0 +1,20 @@
+#pragma once
+
+#include <stdbool.h>
+#include "Types.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+
+// implot.PlotCandlestick() [Candlestick.go]
+// #volumes can be NULL. #width is the fraction of the smallest X spacing a
+// candle takes up, and #volume_height the fraction of the plot height the
+// volume bars take up at its bottom.
+void igpPlotCandlestick(const char *label, const double *xs, const double *opens, const double *closes, const double *lows, const double *highs, const double *volumes, int count, double width, igpVec4 bull_color, igpVec4 bear_color, float volume_height, bool tooltip);
+
+
+#ifdef __cplusplus
+}
+#endif
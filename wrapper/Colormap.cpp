@@ -0,0 +1,33 @@
+
+#include "Colormap.h"
+#include "ImPlot.hpp"
+#include "Wraps.hpp"
+
+
+int igpGetColormapCount() {
+	return ImPlot::GetColormapCount();
+}
+const char *igpGetColormapName(igpColormap cmap) {
+	return ImPlot::GetColormapName(cmap);
+}
+igpColormap igpGetColormapIndex(const char *name) {
+	return ImPlot::GetColormapIndex(name);
+}
+
+void igpPushColormap(igpColormap cmap) { ImPlot::PushColormap(cmap); }
+void igpPushColormapName(const char *name) { ImPlot::PushColormap(name); }
+void igpPopColormap(int count) { ImPlot::PopColormap(count); }
+
+igpVec4 igpNextColormapColor() {
+	return wrapVec4(ImPlot::NextColormapColor());
+}
+
+int igpGetColormapSize(igpColormap cmap) {
+	return ImPlot::GetColormapSize(cmap);
+}
+igpVec4 igpGetColormapColor(int idx, igpColormap cmap) {
+	return wrapVec4(ImPlot::GetColormapColor(idx, cmap));
+}
+igpVec4 igpSampleColormap(float t, igpColormap cmap) {
+	return wrapVec4(ImPlot::SampleColormap(t, cmap));
+}
@@ -0,0 +1,33 @@
+#pragma once
+
+#include "Types.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+
+// implot.GetColormapCount() [Colormap.go]
+int igpGetColormapCount();
+// implot.GetColormapName() [Colormap.go]
+const char *igpGetColormapName(igpColormap cmap);
+// implot.GetColormapIndex() [Colormap.go]
+igpColormap igpGetColormapIndex(const char *name);
+
+// Temporarily switch colormaps
+void igpPushColormap(igpColormap cmap);
+void igpPushColormapName(const char *name);
+void igpPopColormap(int count);
+
+// implot.NextColormapColor() [Colormap.go]
+igpVec4 igpNextColormapColor();
+
+// Colormap utils, cmap=-1 for the current one
+int     igpGetColormapSize(igpColormap cmap);
+igpVec4 igpGetColormapColor(int idx, igpColormap cmap);
+igpVec4 igpSampleColormap(float t, igpColormap cmap);
+
+
+#ifdef __cplusplus
+}
+#endif
@@ -0,0 +1,63 @@
+
+#include "CustomItem.h"
+#include "ImPlot.hpp"
+#include "Wraps.hpp"
+#include "../implot/implot_internal.h"
+
+
+bool igpBeginItem(const char *label, igpStyleCol recolor_from) { return ImPlot::BeginItem(label, recolor_from); }
+void igpEndItem() {
+	IM_ASSERT_USER_ERROR(GImPlot->CurrentItem != NULL, "EndItem() needs to be called after BeginItem() returned true!");
+	ImPlot::EndItem();
+}
+
+bool igpFitThisFrame() { return ImPlot::FitThisFrame(); }
+void igpFitPoint(igpPoint p) {
+	IM_ASSERT_USER_ERROR(GImPlot->CurrentPlot != NULL, "FitPoint() needs to be called between BeginPlot() and EndPlot()!");
+	ImPlot::FitPoint(ImPlotPoint(p.x, p.y));
+}
+void igpFitPointX(double x) {
+	IM_ASSERT_USER_ERROR(GImPlot->CurrentPlot != NULL, "FitPointX() needs to be called between BeginPlot() and EndPlot()!");
+	ImPlot::FitPointX(x);
+}
+void igpFitPointY(double y) {
+	IM_ASSERT_USER_ERROR(GImPlot->CurrentPlot != NULL, "FitPointY() needs to be called between BeginPlot() and EndPlot()!");
+	ImPlot::FitPointY(y);
+}
+
+igpVec2 igpPlotToPixels(igpPoint p) { return wrapVec2(ImPlot::PlotToPixels(p.x, p.y)); }
+igpPoint igpPixelsToPlot(igpVec2 pix) {
+	ImPlotPoint p = ImPlot::PixelsToPlot(unwrapVec2(pix));
+	return igpPoint{p.x, p.y};
+}
+
+void *igpGetPlotDrawList() {
+	IM_ASSERT_USER_ERROR(GImPlot->CurrentPlot != NULL, "GetPlotDrawList() needs to be called between BeginPlot() and EndPlot()!");
+	return ImPlot::GetPlotDrawList();
+}
+
+igpVec4 igpGetCurrentItemColor() {
+	IM_ASSERT_USER_ERROR(GImPlot->CurrentItem != NULL, "GetCurrentItemColor() needs to be called between BeginItem() and EndItem()!");
+	return wrapVec4(ImGui::ColorConvertU32ToFloat4(GImPlot->CurrentItem->Color));
+}
+void igpSetCurrentItemColor(igpVec4 color) {
+	IM_ASSERT_USER_ERROR(GImPlot->CurrentItem != NULL, "SetCurrentItemColor() needs to be called between BeginItem() and EndItem()!");
+	GImPlot->CurrentItem->Color = ImGui::ColorConvertFloat4ToU32(unwrapVec4(color));
+}
+
+igpItemStyle igpGetItemStyle() {
+	IM_ASSERT_USER_ERROR(GImPlot->CurrentItem != NULL, "GetItemStyle() needs to be called between BeginItem() and EndItem()!");
+	const ImPlotNextItemData &s = ImPlot::GetItemData();
+
+	igpItemStyle style;
+	style.line           = wrapVec4(s.Colors[ImPlotCol_Line]);
+	style.fill           = wrapVec4(s.Colors[ImPlotCol_Fill]);
+	style.marker_outline = wrapVec4(s.Colors[ImPlotCol_MarkerOutline]);
+	style.marker_fill    = wrapVec4(s.Colors[ImPlotCol_MarkerFill]);
+	style.line_weight    = s.LineWeight;
+	style.marker         = s.Marker;
+	style.marker_size    = s.MarkerSize;
+	style.marker_weight  = s.MarkerWeight;
+	style.fill_alpha     = s.FillAlpha;
+	return style;
+}
@@ -0,0 +1,48 @@
+#pragma once
+
+#include <stdbool.h>
+#include "Types.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+
+// The resolved style of the current item
+typedef struct {
+	igpVec4   line, fill, marker_outline, marker_fill;
+	float     line_weight;
+	igpMarker marker;
+	float     marker_size, marker_weight, fill_alpha;
+} igpItemStyle;
+
+// implot.BeginItem() [CustomItem.go]
+bool igpBeginItem(const char *label, igpStyleCol recolor_from);
+// implot.EndItem() [CustomItem.go]
+void igpEndItem();
+
+// implot.FitThisFrame() [CustomItem.go]
+bool igpFitThisFrame();
+// implot.FitPoint() [CustomItem.go]
+void igpFitPoint(igpPoint p);
+void igpFitPointX(double x);
+void igpFitPointY(double y);
+
+// implot.PlotToPixels() [CustomItem.go]
+igpVec2 igpPlotToPixels(igpPoint p);
+// implot.PixelsToPlot() [CustomItem.go]
+igpPoint igpPixelsToPlot(igpVec2 pix);
+
+// implot.GetPlotDrawList() [CustomItem.go]
+void *igpGetPlotDrawList();
+
+// implot.GetCurrentItemColor(), SetCurrentItemColor() [CustomItem.go]
+igpVec4 igpGetCurrentItemColor();
+void    igpSetCurrentItemColor(igpVec4 color);
+// implot.GetItemStyle() [CustomItem.go]
+igpItemStyle igpGetItemStyle();
+
+
+#ifdef __cplusplus
+}
+#endif
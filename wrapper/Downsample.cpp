@@ -0,0 +1,16 @@
+
+
+#include "Downsample.h"
+#include "ImPlot.hpp"
+#include "../implot/implot_internal.h"
+
+
+void igpGetAxisView(igpAxis axis, double *vmin, double *vmax, float *pixels, bool *fitting) {
+	IM_ASSERT_USER_ERROR(GImPlot->CurrentPlot != NULL, "PlotLineLTTB() or PlotLineMinMax() needs to be called between BeginPlot() and EndPlot()!");
+	ImPlot::SetupLock();
+	const ImPlotAxis &a = GImPlot->CurrentPlot->Axes[axis];
+	*vmin    = a.Range.Min;
+	*vmax    = a.Range.Max;
+	*pixels  = a.PixelSize();
+	*fitting = a.FitThisFrame;
+}
@@ -0,0 +1,19 @@
+#pragma once
+
+#include <stdbool.h>
+#include "Types.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+
+// implot.PlotLineLTTB(), PlotLineMinMax() [Downsample.go]
+// Locks the setup, then returns the range and pixel length of an axis,
+// and if it is being fitted this frame.
+void igpGetAxisView(igpAxis axis, double *vmin, double *vmax, float *pixels, bool *fitting);
+
+
+#ifdef __cplusplus
+}
+#endif
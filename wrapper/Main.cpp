@@ -10,3 +10,7 @@ const char *igpMain_Version() {
 void igpShowDemoWindow(bool *open) {
 	ImPlot::ShowDemoWindow(open);
 }
+
+int igpGetFrameCount() {
+	return ImGui::GetFrameCount();
+}
@@ -13,6 +13,10 @@ const char *igpMain_Version();
 // implot.ShowDemoWindow() [Main.go]
 void igpShowDemoWindow(bool *open);
 
+// implot.PlotMovingAverage(), PlotViolin()... [Stats.go]
+// Returns the number of the current ImGui frame.
+int igpGetFrameCount();
+
 
 #ifdef __cplusplus
 }
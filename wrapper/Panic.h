@@ -8,6 +8,10 @@ extern "C" {
 // igpPanic calls Go's panic() with the given string.
 __attribute((__noreturn__)) void igpPanic(const char *msg);
 
+// igpPanicAssert calls Go's panic() with an AssertionError.
+// It is used by IM_ASSERT, as defined in implot/imconfig.h.
+__attribute((__noreturn__)) void igpPanicAssert(const char *expr, const char *file, int line);
+
 
 #ifdef __cplusplus
 }
@@ -1,55 +1,280 @@
 
 #include "Plot.h"
 #include "ImPlot.hpp"
+#include "Wraps.hpp"
+#include "../implot/implot_internal.h"
 
 
-void igpPlotLine(const char *label, const double *values, int count, double xscale, double x0) {
-	ImPlot::PlotLine<double>(label, values, count, xscale, x0, 0);
+// Calls FUNC<T>(...) with T being the C type of igpDataType #type.
+#define IGP_DISPATCH(type, FUNC, ...)                                 \
+	switch (type) {                                                   \
+		case igpDataType_Float: FUNC<float>(__VA_ARGS__); break;      \
+		case igpDataType_Double: FUNC<double>(__VA_ARGS__); break;    \
+		case igpDataType_S8: FUNC<ImS8>(__VA_ARGS__); break;          \
+		case igpDataType_U8: FUNC<ImU8>(__VA_ARGS__); break;          \
+		case igpDataType_S16: FUNC<ImS16>(__VA_ARGS__); break;        \
+		case igpDataType_U16: FUNC<ImU16>(__VA_ARGS__); break;        \
+		case igpDataType_S32: FUNC<ImS32>(__VA_ARGS__); break;        \
+		case igpDataType_U32: FUNC<ImU32>(__VA_ARGS__); break;        \
+		case igpDataType_S64: FUNC<ImS64>(__VA_ARGS__); break;        \
+		case igpDataType_U64: FUNC<ImU64>(__VA_ARGS__); break;        \
+	}
+
+
+extern "C" igpPoint igpgoDataGetterCb(uintptr_t handle, int idx);
+
+
+namespace {
+
+// Calls the Go DataGetter with handle #data.
+ImPlotPoint goGetter(void *data, int idx) {
+	return Point(igpgoDataGetterCb(reinterpret_cast<uintptr_t>(data), idx));
+}
+
+// Getters combining two Go getters, or a Go getter and a constant.
+struct combinedGetterData {
+	uintptr_t x, y; // Go DataGetter handles, X from #x and Y from #y
+	double    yref; // used instead of #y if it is 0
+};
+ImPlotPoint combinedGetter(void *data, int idx) {
+	const combinedGetterData &d = *reinterpret_cast<combinedGetterData *>(data);
+
+	ImPlotPoint p = Point(igpgoDataGetterCb(d.x, idx));
+	if (d.y != 0)
+		p.y = igpgoDataGetterCb(d.y, idx).y;
+	else
+		p.y = d.yref;
+	return p;
+}
+
+// ImPlot does not handle items with no points well (some read the first
+// point anyway, or render -1 primitives), so they only get a legend entry,
+// colored from #recolor_from like the item of the plotter.
+bool emptyItem(const char *label, int count, ImPlotCol recolor_from) {
+	if (count > 0)
+		return false;
+	if (ImPlot::BeginItem(label, recolor_from))
+		ImPlot::EndItem();
+	return true;
+}
+
+template<typename T>
+inline const T *cast(const void *p) { return reinterpret_cast<const T *>(p); }
+
+template<typename T>
+void plotLine(const char *label, const void *values, int count, double xscale, double x0, int offset, int stride) {
+	ImPlot::PlotLine<T>(label, cast<T>(values), count, xscale, x0, offset, stride);
+}
+template<typename T>
+void plotLineXY(const char *label, const void *xs, const void *ys, int count, int offset, int stride) {
+	ImPlot::PlotLine<T>(label, cast<T>(xs), cast<T>(ys), count, offset, stride);
 }
-void igpPlotLineXY(const char *label, const double *xs, const double *ys, int count, int stride) {
-	ImPlot::PlotLine<double>(label, xs, ys, count, 0, stride);
+
+template<typename T>
+void plotScatter(const char *label, const void *values, int count, double xscale, double x0, int offset, int stride) {
+	ImPlot::PlotScatter<T>(label, cast<T>(values), count, xscale, x0, offset, stride);
+}
+template<typename T>
+void plotScatterXY(const char *label, const void *xs, const void *ys, int count, int offset, int stride) {
+	ImPlot::PlotScatter<T>(label, cast<T>(xs), cast<T>(ys), count, offset, stride);
 }
 
-void igpPlotScatter(const char *label, const double *values, int count, double xscale, double x0) {
-	ImPlot::PlotScatter<double>(label, values, count, xscale, x0, 0);
+template<typename T>
+void plotStairs(const char *label, const void *values, int count, double xscale, double x0, int offset, int stride) {
+	ImPlot::PlotStairs<T>(label, cast<T>(values), count, xscale, x0, offset, stride);
 }
-void igpPlotScatterXY(const char *label, const double *xs, const double *ys, int count, int stride) {
-	ImPlot::PlotScatter<double>(label, xs, ys, count, 0, stride);
+template<typename T>
+void plotStairsXY(const char *label, const void *xs, const void *ys, int count, int offset, int stride) {
+	ImPlot::PlotStairs<T>(label, cast<T>(xs), cast<T>(ys), count, offset, stride);
 }
 
-void igpPlotStairs(const char *label, const double *values, int count, double xscale, double x0) {
-	ImPlot::PlotStairs<double>(label, values, count, xscale, x0, 0);
+template<typename T>
+void plotShadedRef(const char *label, const void *values, int count, double yref, double xscale, double x0, int offset, int stride) {
+	ImPlot::PlotShaded<T>(label, cast<T>(values), count, yref, xscale, x0, offset, stride);
+}
+template<typename T>
+void plotShadedRefXY(const char *label, const void *xs, const void *ys, int count, double yref, int offset, int stride) {
+	ImPlot::PlotShaded<T>(label, cast<T>(xs), cast<T>(ys), count, yref, offset, stride);
+}
+
+// ImPlot has no PlotShaded(ys1, ys2, xscale, x0), so it is done with getters.
+struct shadedLinesData {
+	const void *ys;
+	int         count, offset, stride;
+	double      xscale, x0;
+};
+template<typename T>
+ImPlotPoint shadedLinesGetter(void *data, int idx) {
+	const shadedLinesData &d = *reinterpret_cast<shadedLinesData *>(data);
+
+	int         i = (d.offset + idx) % d.count;
+	const char *p = reinterpret_cast<const char *>(d.ys) + (size_t)i * d.stride;
+	return ImPlotPoint(d.x0 + d.xscale * idx, (double)*cast<T>(p));
+}
+template<typename T>
+void plotShadedLines(const char *label, const void *ys1, const void *ys2, int count, double xscale, double x0, int offset, int stride) {
+	shadedLinesData d1{ys1, count, offset, stride, xscale, x0};
+	shadedLinesData d2{ys2, count, offset, stride, xscale, x0};
+	ImPlot::PlotShadedG(label, &shadedLinesGetter<T>, &d1, &shadedLinesGetter<T>, &d2, count);
 }
-void igpPlotStairsXY(const char *label, const double *xs, const double *ys, int count, int stride) {
-	ImPlot::PlotStairs<double>(label, xs, ys, count, 0, stride);
+template<typename T>
+void plotShadedLinesXY(const char *label, const void *xs, const void *ys1, const void *ys2, int count, int offset, int stride) {
+	ImPlot::PlotShaded<T>(label, cast<T>(xs), cast<T>(ys1), cast<T>(ys2), count, offset, stride);
 }
 
-void igpPlotShadedRef(const char *label, const double *values, int count, double yref, double xscale, double x0) {
-	ImPlot::PlotShaded<double>(label, values, count, yref, xscale, x0, 0);
+template<typename T>
+void plotBars(const char *label, const void *values, int count, double bar_width, double x0, int offset, int stride) {
+	ImPlot::PlotBars<T>(label, cast<T>(values), count, bar_width, x0, offset, stride);
 }
-void igpPlotShadedRefXY(const char *label, const double *xs, const double *ys, double yref, int count, int stride) {
-	ImPlot::PlotShaded<double>(label, xs, ys, count, yref, 0, stride);
+template<typename T>
+void plotBarsXY(const char *label, const void *xs, const void *ys, int count, double bar_width, int offset, int stride) {
+	ImPlot::PlotBars<T>(label, cast<T>(xs), cast<T>(ys), count, bar_width, offset, stride);
 }
-void igpPlotShadedLinesXY(const char *label, const double *xs, const double *ys1, const double *ys2, int count, int stride) {
-	ImPlot::PlotShaded(label, xs, ys1, ys2, count, 0, stride);
+template<typename T>
+void plotBarsH(const char *label, const void *values, int count, double bar_height, double y0, int offset, int stride) {
+	ImPlot::PlotBarsH<T>(label, cast<T>(values), count, bar_height, y0, offset, stride);
+}
+template<typename T>
+void plotBarsHXY(const char *label, const void *xs, const void *ys, int count, double bar_height, int offset, int stride) {
+	ImPlot::PlotBarsH<T>(label, cast<T>(xs), cast<T>(ys), count, bar_height, offset, stride);
 }
 
-void igpPlotBars(const char *label, const double *values, int count, double bar_width, double x0) {
-	ImPlot::PlotBars(label, values, count, bar_width, x0, 0);
+template<typename T>
+void plotBarGroups(const char **labels, const void *values, int items_per_group, int groups, double group_width, double x0, igpBarGroupsFlags flags) {
+	ImPlot::PlotBarGroups<T>(labels, cast<T>(values), items_per_group, groups, group_width, x0, flags);
 }
-void igpPlotBarsXY(const char *label, const double *xs, const double *ys, int count, double bar_width, int stride) {
-	ImPlot::PlotBars(label, xs, ys, count, bar_width, stride);
+template<typename T>
+void plotBarGroupsH(const char **labels, const void *values, int items_per_group, int groups, double group_height, double y0, igpBarGroupsFlags flags) {
+	ImPlot::PlotBarGroupsH<T>(labels, cast<T>(values), items_per_group, groups, group_height, y0, flags);
 }
-void igpPlotBarsH(const char *label, const double *values, int count, double bar_height, double y0) {
-	ImPlot::PlotBarsH(label, values, count, bar_height, y0, 0);
+
+} // namespace
+
+
+void igpPlotLine(igpDataType type, const char *label, const void *values, int count, double xscale, double x0, int offset, int stride) {
+	if (emptyItem(label, count, ImPlotCol_Line))
+		return;
+	IGP_DISPATCH(type, plotLine, label, values, count, xscale, x0, offset, stride);
 }
-void igpPlotBarsHXY(const char *label, const double *xs, const double *ys, int count, double bar_height, int stride) {
-	ImPlot::PlotBarsH(label, xs, ys, count, bar_height, 0, stride);
+void igpPlotLineXY(igpDataType type, const char *label, const void *xs, const void *ys, int count, int offset, int stride) {
+	if (emptyItem(label, count, ImPlotCol_Line))
+		return;
+	IGP_DISPATCH(type, plotLineXY, label, xs, ys, count, offset, stride);
 }
 
-void igpPlotBarGroups(const char **labels, const double *values, int items_per_group, int groups, double group_width, double x0, igpBarGroupsFlags flags) {
-	ImPlot::PlotBarGroups(labels, values, items_per_group, groups, group_width, x0, flags);
+void igpPlotLineG(const char *label, uintptr_t getter, int count) {
+	if (emptyItem(label, count, ImPlotCol_Line))
+		return;
+	ImPlot::PlotLineG(label, &goGetter, reinterpret_cast<void *>(getter), count);
+}
+
+void igpPlotScatter(igpDataType type, const char *label, const void *values, int count, double xscale, double x0, int offset, int stride) {
+	if (emptyItem(label, count, ImPlotCol_MarkerOutline))
+		return;
+	IGP_DISPATCH(type, plotScatter, label, values, count, xscale, x0, offset, stride);
+}
+void igpPlotScatterXY(igpDataType type, const char *label, const void *xs, const void *ys, int count, int offset, int stride) {
+	if (emptyItem(label, count, ImPlotCol_MarkerOutline))
+		return;
+	IGP_DISPATCH(type, plotScatterXY, label, xs, ys, count, offset, stride);
 }
-void igpPlotBarGroupsH(const char **labels, const double *values, int items_per_group, int groups, double group_height, double y0, igpBarGroupsFlags flags) {
-	ImPlot::PlotBarGroupsH(labels, values, items_per_group, groups, group_height, y0, flags);
+
+void igpPlotScatterG(const char *label, uintptr_t getter, int count) {
+	if (emptyItem(label, count, ImPlotCol_MarkerOutline))
+		return;
+	ImPlot::PlotScatterG(label, &goGetter, reinterpret_cast<void *>(getter), count);
+}
+
+void igpPlotStairs(igpDataType type, const char *label, const void *values, int count, double xscale, double x0, int offset, int stride) {
+	if (emptyItem(label, count, ImPlotCol_Line))
+		return;
+	IGP_DISPATCH(type, plotStairs, label, values, count, xscale, x0, offset, stride);
+}
+void igpPlotStairsXY(igpDataType type, const char *label, const void *xs, const void *ys, int count, int offset, int stride) {
+	if (emptyItem(label, count, ImPlotCol_Line))
+		return;
+	IGP_DISPATCH(type, plotStairsXY, label, xs, ys, count, offset, stride);
+}
+
+void igpPlotStairsG(const char *label, uintptr_t getter, int count) {
+	if (emptyItem(label, count, ImPlotCol_Line))
+		return;
+	ImPlot::PlotStairsG(label, &goGetter, reinterpret_cast<void *>(getter), count);
+}
+
+void igpPlotShadedRef(igpDataType type, const char *label, const void *values, int count, double yref, double xscale, double x0, int offset, int stride) {
+	if (emptyItem(label, count, ImPlotCol_Fill))
+		return;
+	IGP_DISPATCH(type, plotShadedRef, label, values, count, yref, xscale, x0, offset, stride);
+}
+void igpPlotShadedRefXY(igpDataType type, const char *label, const void *xs, const void *ys, int count, double yref, int offset, int stride) {
+	if (emptyItem(label, count, ImPlotCol_Fill))
+		return;
+	IGP_DISPATCH(type, plotShadedRefXY, label, xs, ys, count, yref, offset, stride);
+}
+void igpPlotShadedRefG(const char *label, uintptr_t getter, int count, double yref) {
+	if (emptyItem(label, count, ImPlotCol_Fill))
+		return;
+	combinedGetterData d{getter, 0, yref};
+	ImPlot::PlotShadedG(label, &goGetter, reinterpret_cast<void *>(getter), &combinedGetter, &d, count);
+}
+void igpPlotShadedLines(igpDataType type, const char *label, const void *ys1, const void *ys2, int count, double xscale, double x0, int offset, int stride) {
+	if (emptyItem(label, count, ImPlotCol_Fill))
+		return;
+	IGP_DISPATCH(type, plotShadedLines, label, ys1, ys2, count, xscale, x0, offset, stride);
+}
+void igpPlotShadedLinesXY(igpDataType type, const char *label, const void *xs, const void *ys1, const void *ys2, int count, int offset, int stride) {
+	if (emptyItem(label, count, ImPlotCol_Fill))
+		return;
+	IGP_DISPATCH(type, plotShadedLinesXY, label, xs, ys1, ys2, count, offset, stride);
+}
+
+void igpPlotShadedLinesG(const char *label, uintptr_t getter1, uintptr_t getter2, int count) {
+	if (emptyItem(label, count, ImPlotCol_Fill))
+		return;
+	combinedGetterData d{getter1, getter2, 0};
+	ImPlot::PlotShadedG(label, &goGetter, reinterpret_cast<void *>(getter1), &combinedGetter, &d, count);
+}
+
+void igpPlotBars(igpDataType type, const char *label, const void *values, int count, double bar_width, double x0, int offset, int stride) {
+	if (emptyItem(label, count, ImPlotCol_Fill))
+		return;
+	IGP_DISPATCH(type, plotBars, label, values, count, bar_width, x0, offset, stride);
+}
+void igpPlotBarsXY(igpDataType type, const char *label, const void *xs, const void *ys, int count, double bar_width, int offset, int stride) {
+	if (emptyItem(label, count, ImPlotCol_Fill))
+		return;
+	IGP_DISPATCH(type, plotBarsXY, label, xs, ys, count, bar_width, offset, stride);
+}
+void igpPlotBarsG(const char *label, uintptr_t getter, int count, double bar_width) {
+	if (emptyItem(label, count, ImPlotCol_Fill))
+		return;
+	ImPlot::PlotBarsG(label, &goGetter, reinterpret_cast<void *>(getter), count, bar_width);
+}
+void igpPlotBarsH(igpDataType type, const char *label, const void *values, int count, double bar_height, double y0, int offset, int stride) {
+	if (emptyItem(label, count, ImPlotCol_Fill))
+		return;
+	IGP_DISPATCH(type, plotBarsH, label, values, count, bar_height, y0, offset, stride);
+}
+void igpPlotBarsHXY(igpDataType type, const char *label, const void *xs, const void *ys, int count, double bar_height, int offset, int stride) {
+	if (emptyItem(label, count, ImPlotCol_Fill))
+		return;
+	IGP_DISPATCH(type, plotBarsHXY, label, xs, ys, count, bar_height, offset, stride);
+}
+
+void igpPlotBarsHG(const char *label, uintptr_t getter, int count, double bar_height) {
+	if (emptyItem(label, count, ImPlotCol_Fill))
+		return;
+	ImPlot::PlotBarsHG(label, &goGetter, reinterpret_cast<void *>(getter), count, bar_height);
+}
+
+void igpPlotBarGroups(igpDataType type, const char **labels, const void *values, int items_per_group, int groups, double group_width, double x0, igpBarGroupsFlags flags) {
+	IGP_DISPATCH(type, plotBarGroups, labels, values, items_per_group, groups, group_width, x0, flags);
+}
+void igpPlotBarGroupsH(igpDataType type, const char **labels, const void *values, int items_per_group, int groups, double group_height, double y0, igpBarGroupsFlags flags) {
+	IGP_DISPATCH(type, plotBarGroupsH, labels, values, items_per_group, groups, group_height, y0, flags);
+}
+
+void igpAnnotation(double x, double y, igpVec4 color, igpVec2 offset, bool clamp, const char *text) {
+	ImPlot::Annotation(x, y, unwrapVec4(color), unwrapVec2(offset), clamp, "%s", text);
 }
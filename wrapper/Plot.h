@@ -1,5 +1,7 @@
 #pragma once
 
+#include <stdint.h>
+#include <stdbool.h>
 #include "Types.h"
 
 #ifdef __cplusplus
@@ -7,32 +9,49 @@ extern "C" {
 #endif
 
 
+// All the data pointers below point to #count scalars of type #type,
+// #stride bytes apart, starting from #offset (wrapping around).
+
+// The PlotXXXG functions take a handle to a Go DataGetter,
+// which is called back through igpgoDataGetterCb.
+
 // implot.PlotLine() [Plot.go]
-void igpPlotLine(const char *label, const double *values, int count, double xscale, double x0);
-void igpPlotLineXY(const char *label, const double *xs, const double *ys, int count, int stride);
+void igpPlotLine(igpDataType type, const char *label, const void *values, int count, double xscale, double x0, int offset, int stride);
+void igpPlotLineXY(igpDataType type, const char *label, const void *xs, const void *ys, int count, int offset, int stride);
+void igpPlotLineG(const char *label, uintptr_t getter, int count);
 
 // implot.PlotScatter() [Plot.go]
-void igpPlotScatter(const char *label, const double *values, int count, double xscale, double x0);
-void igpPlotScatterXY(const char *label, const double *xs, const double *ys, int count, int stride);
+void igpPlotScatter(igpDataType type, const char *label, const void *values, int count, double xscale, double x0, int offset, int stride);
+void igpPlotScatterXY(igpDataType type, const char *label, const void *xs, const void *ys, int count, int offset, int stride);
+void igpPlotScatterG(const char *label, uintptr_t getter, int count);
 
 // implot.PlotStairs() [Plot.go]
-void igpPlotStairs(const char *label, const double *values, int count, double xscale, double x0);
-void igpPlotStairsXY(const char *label, const double *xs, const double *ys, int count, int stride);
+void igpPlotStairs(igpDataType type, const char *label, const void *values, int count, double xscale, double x0, int offset, int stride);
+void igpPlotStairsXY(igpDataType type, const char *label, const void *xs, const void *ys, int count, int offset, int stride);
+void igpPlotStairsG(const char *label, uintptr_t getter, int count);
 
 // implot.PlotShadedRef() [Plot.go]
-void igpPlotShadedRef(const char *label, const double *values, int count, double yref, double xscale, double x0);
-void igpPlotShadedRefXY(const char *label, const double *xs, const double *ys, double yref, int count, int stride);
+void igpPlotShadedRef(igpDataType type, const char *label, const void *values, int count, double yref, double xscale, double x0, int offset, int stride);
+void igpPlotShadedRefXY(igpDataType type, const char *label, const void *xs, const void *ys, int count, double yref, int offset, int stride);
+void igpPlotShadedRefG(const char *label, uintptr_t getter, int count, double yref);
 // implot.PlotShadedLines() [Plot.go]
-void igpPlotShadedLinesXY(const char *label, const double *xs, const double *ys1, const double *ys2, int count, int stride);
+void igpPlotShadedLines(igpDataType type, const char *label, const void *ys1, const void *ys2, int count, double xscale, double x0, int offset, int stride);
+void igpPlotShadedLinesXY(igpDataType type, const char *label, const void *xs, const void *ys1, const void *ys2, int count, int offset, int stride);
+void igpPlotShadedLinesG(const char *label, uintptr_t getter1, uintptr_t getter2, int count);
 
 // Vertical/Horizontal bars
-void igpPlotBars(const char *label, const double *values, int count, double bar_width, double x0);
-void igpPlotBarsXY(const char *label, const double *xs, const double *ys, int count, double bar_width, int stride);
-void igpPlotBarsH(const char *label, const double *values, int count, double bar_height, double y0);
-void igpPlotBarsHXY(const char *label, const double *xs, const double *ys, int count, double bar_height, int stride);
+void igpPlotBars(igpDataType type, const char *label, const void *values, int count, double bar_width, double x0, int offset, int stride);
+void igpPlotBarsXY(igpDataType type, const char *label, const void *xs, const void *ys, int count, double bar_width, int offset, int stride);
+void igpPlotBarsG(const char *label, uintptr_t getter, int count, double bar_width);
+void igpPlotBarsH(igpDataType type, const char *label, const void *values, int count, double bar_height, double y0, int offset, int stride);
+void igpPlotBarsHXY(igpDataType type, const char *label, const void *xs, const void *ys, int count, double bar_height, int offset, int stride);
+void igpPlotBarsHG(const char *label, uintptr_t getter, int count, double bar_height);
 // Groups of vertical/horizontal bars
-void igpPlotBarGroups(const char **labels, const double *values, int items_per_group, int groups, double group_width, double x0, igpBarGroupsFlags flags);
-void igpPlotBarGroupsH(const char **labels, const double *values, int items_per_group, int groups, double group_height, double y0, igpBarGroupsFlags flags);
+void igpPlotBarGroups(igpDataType type, const char **labels, const void *values, int items_per_group, int groups, double group_width, double x0, igpBarGroupsFlags flags);
+void igpPlotBarGroupsH(igpDataType type, const char **labels, const void *values, int items_per_group, int groups, double group_height, double y0, igpBarGroupsFlags flags);
+
+// implot.Annotation() [Plot.go]
+void igpAnnotation(double x, double y, igpVec4 color, igpVec2 offset, bool clamp, const char *text);
 
 
 #ifdef __cplusplus
@@ -1,6 +1,7 @@
 
 #include "Setup.h"
 #include "ImPlot.hpp"
+#include "../implot/implot_internal.h"
 
 
 void igpSetupAxis(igpAxis axis, const char *label, igpAxisFlags flags) {
@@ -27,6 +28,14 @@ void igpSetupAxisTickRange(igpAxis axis, double vmin, double vmax, int n, const
 	ImPlot::SetupAxisTicks(axis, vmin, vmax, n, labels, keepDefaults);
 }
 
+void igpGetSetupAxisView(igpAxis axis, double *vmin, double *vmax, float *pixels) {
+	IM_ASSERT_USER_ERROR(GImPlot->CurrentPlot != NULL, "SetupAxisLocator() needs to be called between BeginPlot() and EndPlot()!");
+	const ImPlotAxis &a = GImPlot->CurrentPlot->Axes[axis];
+	*vmin   = a.Range.Min;
+	*vmax   = a.Range.Max;
+	*pixels = a.PixelSize();
+}
+
 void igpSetupAxes(const char *xlabel, const char *ylabel, igpAxisFlags xflags, igpAxisFlags yflags) {
 	ImPlot::SetupAxes(xlabel, ylabel, xflags, yflags);
 }
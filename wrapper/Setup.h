@@ -23,6 +23,10 @@ void igpSetupAxisFormatCallback(igpAxis axis, uintptr_t handle); // TODO implot.
 void igpSetupAxisTickValues(igpAxis axis, const double *values, int n, const char **labels, bool keepDefaults);
 // implot.SetupAxisTickRange() [Setup.go]
 void igpSetupAxisTickRange(igpAxis axis, double vmin, double vmax, int n, const char **labels, bool keepDefaults);
+// implot.SetupAxisLocator() [Locators.go]
+// The visible range and pixel length of an axis, as of the last frame
+// or the SetupAxisLimits call in the current one.
+void igpGetSetupAxisView(igpAxis axis, double *vmin, double *vmax, float *pixels);
 
 void igpSetupAxes(const char *xlabel, const char *ylabel, igpAxisFlags xflags, igpAxisFlags yflags);
 void igpSetupAxesLimits(double xmin, double xmax, double ymin, double ymax, igpCondition cond);
@@ -0,0 +1,36 @@
+
+#include "Time.h"
+#include "ImPlot.hpp"
+#include "../implot/implot_internal.h"
+
+
+namespace {
+inline ImPlotTime unwrapTime(const igpTime &t) {
+	return ImPlotTime((time_t)t.s, t.us);
+}
+inline igpTime wrapTime(const ImPlotTime &t) {
+	return igpTime{(long long)t.S, t.Us};
+}
+} // namespace
+
+
+// implot.ShowDatePicker() [Time.go]
+bool igpShowDatePicker(const char *id, int *level, igpTime *t, const igpTime *t1, const igpTime *t2) {
+	ImPlotTime it = unwrapTime(*t), it1, it2;
+	if (t1 != NULL)
+		it1 = unwrapTime(*t1);
+	if (t2 != NULL)
+		it2 = unwrapTime(*t2);
+
+	bool ok = ImPlot::ShowDatePicker(id, level, &it, t1 ? &it1 : NULL, t2 ? &it2 : NULL);
+	*t      = wrapTime(it);
+	return ok;
+}
+
+// implot.ShowTimePicker() [Time.go]
+bool igpShowTimePicker(const char *id, igpTime *t) {
+	ImPlotTime it = unwrapTime(*t);
+	bool       ok = ImPlot::ShowTimePicker(id, &it);
+	*t            = wrapTime(it);
+	return ok;
+}
@@ -0,0 +1,20 @@
+#pragma once
+
+#include <stdbool.h>
+#include "Types.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+
+// implot.ShowDatePicker() [Time.go]
+bool igpShowDatePicker(const char *id, int *level, igpTime *t, const igpTime *t1, const igpTime *t2);
+
+// implot.ShowTimePicker() [Time.go]
+bool igpShowTimePicker(const char *id, igpTime *t);
+
+
+#ifdef __cplusplus
+}
+#endif
@@ -21,6 +21,21 @@ typedef int igpColormap;
 typedef int igpLocation;
 typedef int igpBin;
 
+// Scalar types of the data passed to PlotXXX, one for each
+// type instantiated in implot_items.cpp.
+typedef enum {
+	igpDataType_Float,
+	igpDataType_Double,
+	igpDataType_S8,
+	igpDataType_U8,
+	igpDataType_S16,
+	igpDataType_U16,
+	igpDataType_S32,
+	igpDataType_U32,
+	igpDataType_S64,
+	igpDataType_U64,
+} igpDataType;
+
 typedef void *igpContext, *iggContext, *igpStyle;
 
 typedef struct {
@@ -35,6 +50,11 @@ typedef struct {
 	double x, y;
 } igpPoint;
 
+typedef struct {
+	long long s;  // second part
+	int       us; // microsecond part
+} igpTime;
+
 
 #ifdef __cplusplus
 }